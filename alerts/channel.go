@@ -0,0 +1,359 @@
+package alerts
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/mail"
+	"net/url"
+	"strings"
+
+	"github.com/Flared/go-flareio"
+)
+
+const channelsPath = "/firework/v2/alerts/channels"
+
+// ChannelType is the kind of destination of a channel.
+type ChannelType string
+
+const (
+	ChannelWebhook    ChannelType = "webhook"
+	ChannelEmail      ChannelType = "email"
+	ChannelSlack      ChannelType = "slack"
+	ChannelTeams      ChannelType = "teams"
+	ChannelSplunk     ChannelType = "splunk"
+	ChannelPagerDuty  ChannelType = "pagerduty"
+	ChannelJira       ChannelType = "jira"
+	ChannelServiceNow ChannelType = "servicenow"
+)
+
+// ChannelSettings configures the destination of a channel.
+//
+// It is one of the settings types of this package, such as
+// *WebhookSettings, or a *RawSettings for types that this package
+// doesn't know.
+type ChannelSettings interface {
+	// ChannelType returns the type of channel that the settings are for.
+	ChannelType() ChannelType
+
+	validate() error
+}
+
+// WebhookSettings sends alerts as JSON to an HTTP endpoint.
+type WebhookSettings struct {
+	Url string `json:"url"`
+
+	// Secret is used to sign the requests, if set.
+	Secret string `json:"secret,omitempty"`
+
+	// Headers are added to the requests.
+	Headers map[string]string `json:"headers,omitempty"`
+}
+
+// EmailSettings sends alerts by email.
+type EmailSettings struct {
+	Recipients []string `json:"recipients"`
+}
+
+// SlackSettings sends alerts to Slack using an incoming webhook.
+type SlackSettings struct {
+	WebhookUrl string `json:"webhook_url"`
+
+	// Channel overrides the channel of the incoming webhook, if set.
+	Channel string `json:"channel,omitempty"`
+}
+
+// TeamsSettings sends alerts to Microsoft Teams using an incoming webhook.
+type TeamsSettings struct {
+	WebhookUrl string `json:"webhook_url"`
+}
+
+// SplunkSettings sends alerts to a Splunk HTTP Event Collector.
+type SplunkSettings struct {
+	Url   string `json:"url"`
+	Token string `json:"token"`
+	Index string `json:"index,omitempty"`
+}
+
+// PagerDutySettings triggers PagerDuty incidents for alerts.
+type PagerDutySettings struct {
+	RoutingKey string `json:"routing_key"`
+}
+
+// JiraSettings creates a Jira issue for each alert.
+type JiraSettings struct {
+	Url        string `json:"url"`
+	Email      string `json:"email"`
+	ApiToken   string `json:"api_token"`
+	ProjectKey string `json:"project_key"`
+	IssueType  string `json:"issue_type,omitempty"`
+}
+
+// ServiceNowSettings creates a ServiceNow incident for each alert.
+type ServiceNowSettings struct {
+	InstanceUrl string `json:"instance_url"`
+	Username    string `json:"username"`
+	Password    string `json:"password"`
+}
+
+// RawSettings are the settings of a channel type that this package
+// doesn't know.
+type RawSettings struct {
+	Type ChannelType
+
+	// Data is the undecoded settings.
+	Data json.RawMessage
+}
+
+func (*WebhookSettings) ChannelType() ChannelType    { return ChannelWebhook }
+func (*EmailSettings) ChannelType() ChannelType      { return ChannelEmail }
+func (*SlackSettings) ChannelType() ChannelType      { return ChannelSlack }
+func (*TeamsSettings) ChannelType() ChannelType      { return ChannelTeams }
+func (*SplunkSettings) ChannelType() ChannelType     { return ChannelSplunk }
+func (*PagerDutySettings) ChannelType() ChannelType  { return ChannelPagerDuty }
+func (*JiraSettings) ChannelType() ChannelType       { return ChannelJira }
+func (*ServiceNowSettings) ChannelType() ChannelType { return ChannelServiceNow }
+func (settings *RawSettings) ChannelType() ChannelType {
+	return settings.Type
+}
+
+func (settings *WebhookSettings) validate() error {
+	return validateUrl("url", settings.Url)
+}
+
+func (settings *EmailSettings) validate() error {
+	if len(settings.Recipients) == 0 {
+		return errors.New("email channel has no recipients")
+	}
+	for _, recipient := range settings.Recipients {
+		address, err := mail.ParseAddress(recipient)
+		if err != nil || address.Address != recipient {
+			return fmt.Errorf("invalid recipient %q", recipient)
+		}
+	}
+	return nil
+}
+
+func (settings *SlackSettings) validate() error {
+	return validateUrl("webhook_url", settings.WebhookUrl)
+}
+
+func (settings *TeamsSettings) validate() error {
+	return validateUrl("webhook_url", settings.WebhookUrl)
+}
+
+func (settings *SplunkSettings) validate() error {
+	if settings.Token == "" {
+		return errors.New("splunk channel has no token")
+	}
+	return validateUrl("url", settings.Url)
+}
+
+func (settings *PagerDutySettings) validate() error {
+	if settings.RoutingKey == "" {
+		return errors.New("pagerduty channel has no routing key")
+	}
+	return nil
+}
+
+func (settings *JiraSettings) validate() error {
+	if settings.Email == "" || settings.ApiToken == "" || settings.ProjectKey == "" {
+		return errors.New("jira channel requires an email, an api token and a project key")
+	}
+	return validateUrl("url", settings.Url)
+}
+
+func (settings *ServiceNowSettings) validate() error {
+	if settings.Username == "" || settings.Password == "" {
+		return errors.New("servicenow channel requires a username and a password")
+	}
+	return validateUrl("instance_url", settings.InstanceUrl)
+}
+
+func (settings *RawSettings) validate() error {
+	if settings.Type == "" {
+		return errors.New("channel has no type")
+	}
+	return nil
+}
+
+func validateUrl(field string, value string) error {
+	parsed, err := url.Parse(value)
+	if err != nil || (parsed.Scheme != "https" && parsed.Scheme != "http") || parsed.Host == "" {
+		return fmt.Errorf("invalid %s %q: must be an http or https url", field, value)
+	}
+	return nil
+}
+
+var channelTypes = map[ChannelType]func() ChannelSettings{
+	ChannelWebhook:    func() ChannelSettings { return &WebhookSettings{} },
+	ChannelEmail:      func() ChannelSettings { return &EmailSettings{} },
+	ChannelSlack:      func() ChannelSettings { return &SlackSettings{} },
+	ChannelTeams:      func() ChannelSettings { return &TeamsSettings{} },
+	ChannelSplunk:     func() ChannelSettings { return &SplunkSettings{} },
+	ChannelPagerDuty:  func() ChannelSettings { return &PagerDutySettings{} },
+	ChannelJira:       func() ChannelSettings { return &JiraSettings{} },
+	ChannelServiceNow: func() ChannelSettings { return &ServiceNowSettings{} },
+}
+
+// Channel is a destination that alerts are sent to.
+type Channel struct {
+	// Id is set by the API when the channel is created.
+	Id int64
+
+	Name       string
+	IsDisabled bool
+
+	// Settings configure the destination, and determine the type of
+	// the channel.
+	Settings ChannelSettings
+}
+
+type channelJSON struct {
+	Id         int64           `json:"id,omitempty"`
+	Name       string          `json:"name"`
+	Type       ChannelType     `json:"type"`
+	IsDisabled bool            `json:"is_disabled"`
+	Settings   json.RawMessage `json:"settings"`
+}
+
+// MarshalJSON encodes the channel with its type next to its settings.
+func (channel Channel) MarshalJSON() ([]byte, error) {
+	encoded := channelJSON{
+		Id:         channel.Id,
+		Name:       channel.Name,
+		IsDisabled: channel.IsDisabled,
+		Settings:   json.RawMessage("null"),
+	}
+	if raw, isRaw := channel.Settings.(*RawSettings); isRaw {
+		encoded.Type = raw.Type
+		if len(raw.Data) > 0 {
+			encoded.Settings = raw.Data
+		}
+	} else if channel.Settings != nil {
+		encoded.Type = channel.Settings.ChannelType()
+		settings, err := json.Marshal(channel.Settings)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode %s settings: %w", encoded.Type, err)
+		}
+		encoded.Settings = settings
+	}
+	return json.Marshal(encoded)
+}
+
+// UnmarshalJSON decodes the settings of the channel into the type that
+// matches the type of the channel, or into a *RawSettings if its type
+// is unknown.
+func (channel *Channel) UnmarshalJSON(data []byte) error {
+	var decoded channelJSON
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+
+	var settings ChannelSettings
+	if newSettings, known := channelTypes[decoded.Type]; known {
+		settings = newSettings()
+		if len(decoded.Settings) > 0 {
+			if err := json.Unmarshal(decoded.Settings, settings); err != nil {
+				return fmt.Errorf("failed to decode %s settings: %w", decoded.Type, err)
+			}
+		}
+	} else {
+		settings = &RawSettings{
+			Type: decoded.Type,
+			Data: decoded.Settings,
+		}
+	}
+
+	*channel = Channel{
+		Id:         decoded.Id,
+		Name:       decoded.Name,
+		IsDisabled: decoded.IsDisabled,
+		Settings:   settings,
+	}
+	return nil
+}
+
+// Validate returns an error if the channel is invalid for its type.
+func (channel *Channel) Validate() error {
+	if strings.TrimSpace(channel.Name) == "" {
+		return errors.New("channel has no name")
+	}
+	if channel.Settings == nil {
+		return errors.New("channel has no settings")
+	}
+	if err := channel.Settings.validate(); err != nil {
+		return fmt.Errorf("invalid channel %q: %w", channel.Name, err)
+	}
+	return nil
+}
+
+func channelPath(id int64) string {
+	return fmt.Sprintf("%s/%d", channelsPath, id)
+}
+
+// CreateChannel creates the channel and returns it as created by the API.
+func (client *Client) CreateChannel(ctx context.Context, channel Channel) (*Channel, error) {
+	if err := channel.Validate(); err != nil {
+		return nil, err
+	}
+	var created Channel
+	if err := client.apiClient.DoJson(ctx, http.MethodPost, channelsPath, channel, &created); err != nil {
+		return nil, fmt.Errorf("failed to create channel: %w", err)
+	}
+	return &created, nil
+}
+
+// GetChannel fetches the channel with the given id.
+func (client *Client) GetChannel(ctx context.Context, id int64) (*Channel, error) {
+	var channel Channel
+	if err := client.apiClient.DoJson(ctx, http.MethodGet, channelPath(id), nil, &channel); err != nil {
+		return nil, fmt.Errorf("failed to get channel: %w", err)
+	}
+	return &channel, nil
+}
+
+// ListChannels fetches all the channels of the tenant.
+func (client *Client) ListChannels(ctx context.Context) ([]*Channel, error) {
+	channels, err := flareio.ListAll[Channel](ctx, client.apiClient, channelsPath, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list channels: %w", err)
+	}
+	return channels, nil
+}
+
+// UpdateChannel replaces the channel that has the id of the given
+// channel, and returns it as updated by the API.
+func (client *Client) UpdateChannel(ctx context.Context, channel Channel) (*Channel, error) {
+	if channel.Id == 0 {
+		return nil, errors.New("channel to update has no id")
+	}
+	if err := channel.Validate(); err != nil {
+		return nil, err
+	}
+	var updated Channel
+	if err := client.apiClient.DoJson(ctx, http.MethodPut, channelPath(channel.Id), channel, &updated); err != nil {
+		return nil, fmt.Errorf("failed to update channel: %w", err)
+	}
+	return &updated, nil
+}
+
+// DeleteChannel deletes the channel with the given id.
+func (client *Client) DeleteChannel(ctx context.Context, id int64) error {
+	if err := client.apiClient.DoJson(ctx, http.MethodDelete, channelPath(id), nil, nil); err != nil {
+		return fmt.Errorf("failed to delete channel: %w", err)
+	}
+	return nil
+}
+
+// SendTestAlert asks the API to send a sample alert to the channel
+// with the given id, which allows checking that it is configured
+// properly.
+func (client *Client) SendTestAlert(ctx context.Context, id int64) error {
+	if err := client.apiClient.DoJson(ctx, http.MethodPost, channelPath(id)+"/_test", nil, nil); err != nil {
+		return fmt.Errorf("failed to send test alert: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,155 @@
+package alerts
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/Flared/go-flareio"
+	"github.com/Flared/go-flareio/flareiotest"
+	"github.com/stretchr/testify/assert"
+)
+
+// newFakeApi serves channels, feeds and rules from memory.
+func newFakeApi() (*flareiotest.CrudApi, *flareiotest.Server) {
+	api := flareiotest.NewCrudApi(channelsPath, feedsPath, rulesPath)
+	return api, flareiotest.NewServer(api)
+}
+
+func TestChannelJSON(t *testing.T) {
+	channel := Channel{
+		Id:   3,
+		Name: "SOC",
+		Settings: &SlackSettings{
+			WebhookUrl: "https://hooks.slack.com/services/T0/B0/X",
+			Channel:    "#soc",
+		},
+	}
+	encoded, err := json.Marshal(channel)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.JSONEq(
+		t,
+		`{
+			"id": 3,
+			"name": "SOC",
+			"type": "slack",
+			"is_disabled": false,
+			"settings": {"webhook_url": "https://hooks.slack.com/services/T0/B0/X", "channel": "#soc"}
+		}`,
+		string(encoded),
+	)
+
+	var decoded Channel
+	if assert.NoError(t, json.Unmarshal(encoded, &decoded)) {
+		assert.Equal(t, channel, decoded)
+	}
+
+	unknown := []byte(`{"id": 4, "name": "Chat", "type": "mattermost", "settings": {"url": "https://chat"}}`)
+	if assert.NoError(t, json.Unmarshal(unknown, &decoded)) {
+		assert.Equal(t, &RawSettings{
+			Type: "mattermost",
+			Data: json.RawMessage(`{"url": "https://chat"}`),
+		}, decoded.Settings)
+		assert.Equal(t, ChannelType("mattermost"), decoded.Settings.ChannelType())
+	}
+
+	reencoded, err := json.Marshal(decoded)
+	if assert.NoError(t, err) {
+		assert.JSONEq(t, string(unknown)[:len(unknown)-1]+`, "is_disabled": false}`, string(reencoded))
+	}
+}
+
+func TestChannelValidate(t *testing.T) {
+	for _, settings := range []ChannelSettings{
+		&WebhookSettings{Url: "https://example.com/alerts"},
+		&EmailSettings{Recipients: []string{"soc@example.com"}},
+		&SlackSettings{WebhookUrl: "https://hooks.slack.com/services/T0/B0/X"},
+		&TeamsSettings{WebhookUrl: "https://example.webhook.office.com/x"},
+		&SplunkSettings{Url: "https://splunk.example.com:8088", Token: "token"},
+		&PagerDutySettings{RoutingKey: "key"},
+		&JiraSettings{Url: "https://example.atlassian.net", Email: "bot@example.com", ApiToken: "token", ProjectKey: "SEC"},
+		&ServiceNowSettings{InstanceUrl: "https://example.service-now.com", Username: "bot", Password: "secret"},
+		&RawSettings{Type: "mattermost"},
+	} {
+		channel := Channel{Name: "Channel", Settings: settings}
+		assert.NoError(t, channel.Validate(), "%s channel should be valid", settings.ChannelType())
+	}
+
+	for _, tc := range []struct {
+		channel  Channel
+		expected string
+	}{
+		{Channel{Settings: &PagerDutySettings{RoutingKey: "key"}}, "channel has no name"},
+		{Channel{Name: "Hook"}, "channel has no settings"},
+		{Channel{Name: "Hook", Settings: &WebhookSettings{Url: "ftp://example.com"}}, `invalid url "ftp://example.com"`},
+		{Channel{Name: "Mail", Settings: &EmailSettings{}}, "email channel has no recipients"},
+		{Channel{Name: "Mail", Settings: &EmailSettings{Recipients: []string{"soc"}}}, `invalid recipient "soc"`},
+		{Channel{Name: "Slack", Settings: &SlackSettings{}}, `invalid webhook_url ""`},
+		{Channel{Name: "Splunk", Settings: &SplunkSettings{Url: "https://splunk"}}, "splunk channel has no token"},
+		{Channel{Name: "Jira", Settings: &JiraSettings{Url: "https://jira"}}, "jira channel requires"},
+		{Channel{Name: "Other", Settings: &RawSettings{}}, "channel has no type"},
+	} {
+		assert.ErrorContains(t, tc.channel.Validate(), tc.expected)
+	}
+}
+
+func TestChannelCrud(t *testing.T) {
+	api, server := newFakeApi()
+	defer server.Close()
+
+	ctx := context.Background()
+	client := NewClient(server.Client())
+
+	created, err := client.CreateChannel(ctx, Channel{
+		Name:     "SOC webhook",
+		Settings: &WebhookSettings{Url: "https://example.com/alerts", Secret: "secret"},
+	})
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, &WebhookSettings{Url: "https://example.com/alerts", Secret: "secret"}, created.Settings)
+
+	created.Settings = &TeamsSettings{WebhookUrl: "https://example.webhook.office.com/x"}
+	_, err = client.UpdateChannel(ctx, *created)
+	assert.NoError(t, err)
+
+	fetched, err := client.GetChannel(ctx, created.Id)
+	if assert.NoError(t, err) {
+		assert.Equal(t, ChannelTeams, fetched.Settings.ChannelType())
+	}
+
+	channels, err := client.ListChannels(ctx)
+	if assert.NoError(t, err) {
+		assert.Equal(t, []*Channel{fetched}, channels)
+	}
+
+	assert.NoError(t, client.SendTestAlert(ctx, created.Id))
+
+	assert.NoError(t, client.DeleteChannel(ctx, created.Id))
+	err = client.SendTestAlert(ctx, created.Id)
+	assert.EqualError(t, err, "failed to send test alert: got http status code 404: not found")
+	var apiErr *flareio.ApiError
+	if assert.ErrorAs(t, err, &apiErr) {
+		assert.Equal(t, http.StatusNotFound, apiErr.StatusCode)
+	}
+
+	_, err = client.UpdateChannel(ctx, Channel{Name: "No id", Settings: &PagerDutySettings{RoutingKey: "key"}})
+	assert.EqualError(t, err, "channel to update has no id")
+
+	assert.Equal(
+		t,
+		[]string{
+			"POST /firework/v2/alerts/channels",
+			"PUT /firework/v2/alerts/channels/1",
+			"GET /firework/v2/alerts/channels/1",
+			"GET /firework/v2/alerts/channels",
+			"POST /firework/v2/alerts/channels/1/_test",
+			"DELETE /firework/v2/alerts/channels/1",
+			"POST /firework/v2/alerts/channels/1/_test",
+		},
+		api.Requests(),
+	)
+}
@@ -0,0 +1,22 @@
+// Package alerts allows managing how the alerts of a tenant are routed:
+// feeds select events, and rules send the events of a feed to channels
+// such as webhooks, emails or Slack.
+package alerts
+
+import "github.com/Flared/go-flareio"
+
+// Client allows using the alerting endpoints of the Flare API.
+//
+// Its methods return an *flareio.ApiError if the API responds with an
+// unsuccessful status.
+type Client struct {
+	apiClient *flareio.ApiClient
+}
+
+// NewClient can be used to create a new Client that performs its
+// requests with the given ApiClient.
+func NewClient(apiClient *flareio.ApiClient) *Client {
+	return &Client{
+		apiClient: apiClient,
+	}
+}
@@ -0,0 +1,101 @@
+package alerts
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/Flared/go-flareio"
+	"github.com/Flared/go-flareio/events"
+)
+
+const feedsPath = "/firework/v2/alerts/feeds"
+
+// Feed selects the events of the tenant that can be alerted on.
+type Feed struct {
+	// Id is set by the API when the feed is created.
+	Id int64 `json:"id,omitempty"`
+
+	Name string `json:"name"`
+
+	// Query is a query string that events must match, if set.
+	Query string `json:"query,omitempty"`
+
+	// EventTypes restricts the feed to events of these types, if set.
+	EventTypes []events.EventType `json:"event_types,omitempty"`
+
+	// Severities restricts the feed to events of these severities, if set.
+	Severities []events.Severity `json:"severities,omitempty"`
+
+	// IdentifierIds restricts the feed to events that matched these
+	// identifiers, if set.
+	IdentifierIds []int64 `json:"identifier_ids,omitempty"`
+}
+
+// Validate returns an error if the feed is invalid.
+func (feed *Feed) Validate() error {
+	if strings.TrimSpace(feed.Name) == "" {
+		return errors.New("feed has no name")
+	}
+	return nil
+}
+
+func feedPath(id int64) string {
+	return fmt.Sprintf("%s/%d", feedsPath, id)
+}
+
+// CreateFeed creates the feed and returns it as created by the API.
+func (client *Client) CreateFeed(ctx context.Context, feed Feed) (*Feed, error) {
+	if err := feed.Validate(); err != nil {
+		return nil, err
+	}
+	var created Feed
+	if err := client.apiClient.DoJson(ctx, http.MethodPost, feedsPath, feed, &created); err != nil {
+		return nil, fmt.Errorf("failed to create feed: %w", err)
+	}
+	return &created, nil
+}
+
+// GetFeed fetches the feed with the given id.
+func (client *Client) GetFeed(ctx context.Context, id int64) (*Feed, error) {
+	var feed Feed
+	if err := client.apiClient.DoJson(ctx, http.MethodGet, feedPath(id), nil, &feed); err != nil {
+		return nil, fmt.Errorf("failed to get feed: %w", err)
+	}
+	return &feed, nil
+}
+
+// ListFeeds fetches all the feeds of the tenant.
+func (client *Client) ListFeeds(ctx context.Context) ([]*Feed, error) {
+	feeds, err := flareio.ListAll[Feed](ctx, client.apiClient, feedsPath, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list feeds: %w", err)
+	}
+	return feeds, nil
+}
+
+// UpdateFeed replaces the feed that has the id of the given feed, and
+// returns it as updated by the API.
+func (client *Client) UpdateFeed(ctx context.Context, feed Feed) (*Feed, error) {
+	if feed.Id == 0 {
+		return nil, errors.New("feed to update has no id")
+	}
+	if err := feed.Validate(); err != nil {
+		return nil, err
+	}
+	var updated Feed
+	if err := client.apiClient.DoJson(ctx, http.MethodPut, feedPath(feed.Id), feed, &updated); err != nil {
+		return nil, fmt.Errorf("failed to update feed: %w", err)
+	}
+	return &updated, nil
+}
+
+// DeleteFeed deletes the feed with the given id.
+func (client *Client) DeleteFeed(ctx context.Context, id int64) error {
+	if err := client.apiClient.DoJson(ctx, http.MethodDelete, feedPath(id), nil, nil); err != nil {
+		return fmt.Errorf("failed to delete feed: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,46 @@
+package alerts
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Flared/go-flareio/events"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFeedCrud(t *testing.T) {
+	api, server := newFakeApi()
+	defer server.Close()
+
+	ctx := context.Background()
+	client := NewClient(server.Client())
+
+	_, err := client.CreateFeed(ctx, Feed{Name: " "})
+	assert.EqualError(t, err, "feed has no name")
+
+	created, err := client.CreateFeed(ctx, Feed{
+		Name:       "Critical leaks",
+		EventTypes: []events.EventType{events.TypeLeakedCredential, events.TypeStealerLog},
+		Severities: []events.Severity{events.SeverityCritical},
+	})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	created.IdentifierIds = []int64{12}
+	_, err = client.UpdateFeed(ctx, *created)
+	assert.NoError(t, err)
+
+	fetched, err := client.GetFeed(ctx, created.Id)
+	if assert.NoError(t, err) {
+		assert.Equal(t, created, fetched)
+	}
+
+	feeds, err := client.ListFeeds(ctx)
+	if assert.NoError(t, err) {
+		assert.Equal(t, []*Feed{created}, feeds)
+	}
+
+	assert.NoError(t, client.DeleteFeed(ctx, created.Id))
+	assert.Empty(t, api.Objects(feedsPath))
+}
@@ -0,0 +1,117 @@
+package alerts
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/Flared/go-flareio"
+)
+
+const rulesPath = "/firework/v2/alerts/rules"
+
+// Frequency is how often a rule sends the new events of its feed.
+type Frequency string
+
+const (
+	FrequencyImmediate Frequency = "immediate"
+	FrequencyHourly    Frequency = "hourly"
+	FrequencyDaily     Frequency = "daily"
+	FrequencyWeekly    Frequency = "weekly"
+)
+
+// Rule sends the events of a feed to channels.
+type Rule struct {
+	// Id is set by the API when the rule is created.
+	Id int64 `json:"id,omitempty"`
+
+	Name   string `json:"name"`
+	FeedId int64  `json:"feed_id"`
+
+	// ChannelIds are the channels that alerts are sent to.
+	ChannelIds []int64 `json:"channel_ids"`
+
+	// Frequency defaults to FrequencyImmediate when it is empty.
+	Frequency Frequency `json:"frequency,omitempty"`
+
+	IsDisabled bool `json:"is_disabled"`
+}
+
+// Validate returns an error if the rule is invalid.
+func (rule *Rule) Validate() error {
+	if strings.TrimSpace(rule.Name) == "" {
+		return errors.New("rule has no name")
+	}
+	if rule.FeedId == 0 {
+		return fmt.Errorf("rule %q has no feed", rule.Name)
+	}
+	if len(rule.ChannelIds) == 0 {
+		return fmt.Errorf("rule %q has no channels", rule.Name)
+	}
+	switch rule.Frequency {
+	case "", FrequencyImmediate, FrequencyHourly, FrequencyDaily, FrequencyWeekly:
+		return nil
+	default:
+		return fmt.Errorf("rule %q has an unknown frequency %q", rule.Name, rule.Frequency)
+	}
+}
+
+func rulePath(id int64) string {
+	return fmt.Sprintf("%s/%d", rulesPath, id)
+}
+
+// CreateRule creates the rule and returns it as created by the API.
+func (client *Client) CreateRule(ctx context.Context, rule Rule) (*Rule, error) {
+	if err := rule.Validate(); err != nil {
+		return nil, err
+	}
+	var created Rule
+	if err := client.apiClient.DoJson(ctx, http.MethodPost, rulesPath, rule, &created); err != nil {
+		return nil, fmt.Errorf("failed to create rule: %w", err)
+	}
+	return &created, nil
+}
+
+// GetRule fetches the rule with the given id.
+func (client *Client) GetRule(ctx context.Context, id int64) (*Rule, error) {
+	var rule Rule
+	if err := client.apiClient.DoJson(ctx, http.MethodGet, rulePath(id), nil, &rule); err != nil {
+		return nil, fmt.Errorf("failed to get rule: %w", err)
+	}
+	return &rule, nil
+}
+
+// ListRules fetches all the rules of the tenant.
+func (client *Client) ListRules(ctx context.Context) ([]*Rule, error) {
+	rules, err := flareio.ListAll[Rule](ctx, client.apiClient, rulesPath, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list rules: %w", err)
+	}
+	return rules, nil
+}
+
+// UpdateRule replaces the rule that has the id of the given rule, and
+// returns it as updated by the API.
+func (client *Client) UpdateRule(ctx context.Context, rule Rule) (*Rule, error) {
+	if rule.Id == 0 {
+		return nil, errors.New("rule to update has no id")
+	}
+	if err := rule.Validate(); err != nil {
+		return nil, err
+	}
+	var updated Rule
+	if err := client.apiClient.DoJson(ctx, http.MethodPut, rulePath(rule.Id), rule, &updated); err != nil {
+		return nil, fmt.Errorf("failed to update rule: %w", err)
+	}
+	return &updated, nil
+}
+
+// DeleteRule deletes the rule with the given id.
+func (client *Client) DeleteRule(ctx context.Context, id int64) error {
+	if err := client.apiClient.DoJson(ctx, http.MethodDelete, rulePath(id), nil, nil); err != nil {
+		return fmt.Errorf("failed to delete rule: %w", err)
+	}
+	return nil
+}
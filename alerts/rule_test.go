@@ -0,0 +1,74 @@
+package alerts
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRuleValidate(t *testing.T) {
+	valid := Rule{Name: "Critical", FeedId: 1, ChannelIds: []int64{2}}
+	assert.NoError(t, valid.Validate())
+
+	for _, tc := range []struct {
+		rule     Rule
+		expected string
+	}{
+		{Rule{FeedId: 1, ChannelIds: []int64{2}}, "rule has no name"},
+		{Rule{Name: "Critical", ChannelIds: []int64{2}}, `rule "Critical" has no feed`},
+		{Rule{Name: "Critical", FeedId: 1}, `rule "Critical" has no channels`},
+		{Rule{Name: "Critical", FeedId: 1, ChannelIds: []int64{2}, Frequency: "monthly"}, `rule "Critical" has an unknown frequency "monthly"`},
+	} {
+		assert.EqualError(t, tc.rule.Validate(), tc.expected)
+	}
+}
+
+func TestRuleCrud(t *testing.T) {
+	api, server := newFakeApi()
+	defer server.Close()
+
+	ctx := context.Background()
+	client := NewClient(server.Client())
+
+	feed, err := client.CreateFeed(ctx, Feed{Name: "Critical leaks"})
+	if !assert.NoError(t, err) {
+		return
+	}
+	channel, err := client.CreateChannel(ctx, Channel{
+		Name:     "SOC",
+		Settings: &EmailSettings{Recipients: []string{"soc@example.com"}},
+	})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	created, err := client.CreateRule(ctx, Rule{
+		Name:       "Critical leaks to SOC",
+		FeedId:     feed.Id,
+		ChannelIds: []int64{channel.Id},
+	})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	created.Frequency = FrequencyDaily
+	created.IsDisabled = true
+	_, err = client.UpdateRule(ctx, *created)
+	assert.NoError(t, err)
+
+	rules, err := client.ListRules(ctx)
+	if assert.NoError(t, err) {
+		assert.Equal(t, []*Rule{created}, rules)
+	}
+
+	fetched, err := client.GetRule(ctx, created.Id)
+	if assert.NoError(t, err) {
+		assert.Equal(t, FrequencyDaily, fetched.Frequency)
+	}
+
+	assert.NoError(t, client.DeleteRule(ctx, created.Id))
+	_, err = client.GetRule(ctx, created.Id)
+	assert.EqualError(t, err, "failed to get rule: got http status code 404: not found")
+	assert.Empty(t, api.Objects(rulesPath))
+}
@@ -2,11 +2,13 @@ package flareio
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
+	"sync"
 	"time"
 
 	"github.com/hashicorp/go-retryablehttp"
@@ -17,6 +19,9 @@ type ApiClient struct {
 	apiKey      string
 	httpClient  *retryablehttp.Client
 	baseUrl     string
+	rateLimiter *rateLimiter
+
+	apiTokenMu  sync.Mutex
 	apiToken    string
 	apiTokenExp time.Time
 }
@@ -30,8 +35,18 @@ func WithTenantId(tenantId int) ApiClientOption {
 	}
 }
 
-// withBaseUrl allows configuring the base url, for testing only.
-func withBaseUrl(baseUrl string) ApiClientOption {
+// WithRequestInterval allows configuring the minimum delay between
+// two requests made by the client, including requests made
+// concurrently by the paging iterators.
+func WithRequestInterval(interval time.Duration) ApiClientOption {
+	return func(client *ApiClient) {
+		client.rateLimiter = newRateLimiter(interval)
+	}
+}
+
+// WithBaseUrl allows configuring the base url of the API, such as the
+// url of a fake server used for testing.
+func WithBaseUrl(baseUrl string) ApiClientOption {
 	return func(client *ApiClient) {
 		client.baseUrl = baseUrl
 	}
@@ -70,6 +85,13 @@ func NewApiClient(
 // GenerateToken creates a Flare API token using the
 // API Client's API key.
 func (client *ApiClient) GenerateToken() (string, error) {
+	client.apiTokenMu.Lock()
+	defer client.apiTokenMu.Unlock()
+	return client.generateToken(context.Background())
+}
+
+// generateToken must be called with apiTokenMu held.
+func (client *ApiClient) generateToken(ctx context.Context) (string, error) {
 	// Prepare payload
 	type GeneratePayload struct {
 		TenantId int `json:"tenant_id,omitempty"`
@@ -84,6 +106,7 @@ func (client *ApiClient) GenerateToken() (string, error) {
 
 	// Prepare the request
 	request, err := client.newRequest(
+		ctx,
 		"POST",
 		"/tokens/generate",
 		nil,
@@ -123,14 +146,17 @@ func (client *ApiClient) isApiTokenExpired() bool {
 	return client.apiTokenExp.Before(time.Now())
 }
 
-func (client *ApiClient) getOrGenerateToken() (string, error) {
+func (client *ApiClient) getOrGenerateToken(ctx context.Context) (string, error) {
+	client.apiTokenMu.Lock()
+	defer client.apiTokenMu.Unlock()
 	if !client.isApiTokenExpired() {
 		return client.apiToken, nil
 	}
-	return client.GenerateToken()
+	return client.generateToken(ctx)
 }
 
 func (client *ApiClient) newRequest(
+	ctx context.Context,
 	method string,
 	path string,
 	params *url.Values,
@@ -140,18 +166,24 @@ func (client *ApiClient) newRequest(
 	if err != nil {
 		return nil, fmt.Errorf("failed to create dest URL: %w", err)
 	}
-	if params != nil {
+	if params != nil && len(*params) > 0 {
 		destUrl = destUrl + "?" + params.Encode()
 	}
-	return http.NewRequest(method, destUrl, body)
+	return http.NewRequestWithContext(ctx, method, destUrl, body)
 }
 
 func (client *ApiClient) do(
 	request *http.Request,
 	authenticated bool,
 ) (*http.Response, error) {
+	if client.rateLimiter != nil {
+		if err := client.rateLimiter.wait(request.Context()); err != nil {
+			return nil, fmt.Errorf("failed to wait for rate limiter: %w", err)
+		}
+	}
+
 	if authenticated {
-		apiToken, err := client.getOrGenerateToken()
+		apiToken, err := client.getOrGenerateToken(request.Context())
 		if err != nil {
 			return nil, err
 		}
@@ -175,7 +207,16 @@ func (client *ApiClient) do(
 // Get peforms an authenticated GET request at the given path.
 // Includes params in the query string.
 func (client *ApiClient) Get(path string, params *url.Values) (*http.Response, error) {
-	request, err := client.newRequest("GET", path, params, nil)
+	return client.GetWithContext(context.Background(), path, params)
+}
+
+// GetWithContext is like Get but uses the given context for the request.
+func (client *ApiClient) GetWithContext(
+	ctx context.Context,
+	path string,
+	params *url.Values,
+) (*http.Response, error) {
+	request, err := client.newRequest(ctx, "GET", path, params, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create http request: %w", err)
 	}
@@ -191,10 +232,96 @@ func (client *ApiClient) Post(
 	contentType string,
 	body io.Reader,
 ) (*http.Response, error) {
-	request, err := client.newRequest("POST", path, params, body)
+	return client.PostWithContext(context.Background(), path, params, contentType, body)
+}
+
+// PostWithContext is like Post but uses the given context for the request.
+func (client *ApiClient) PostWithContext(
+	ctx context.Context,
+	path string,
+	params *url.Values,
+	contentType string,
+	body io.Reader,
+) (*http.Response, error) {
+	request, err := client.newRequest(ctx, "POST", path, params, body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create http request: %w", err)
+	}
+	request.Header.Set("Content-Type", contentType)
+	return client.do(request, true)
+}
+
+// Put performs an authenticated PUT request at the given path.
+// Includes params in the query string.
+// The provided ContentType should describe the content of the body.
+func (client *ApiClient) Put(
+	path string,
+	params *url.Values,
+	contentType string,
+	body io.Reader,
+) (*http.Response, error) {
+	return client.PutWithContext(context.Background(), path, params, contentType, body)
+}
+
+// PutWithContext is like Put but uses the given context for the request.
+func (client *ApiClient) PutWithContext(
+	ctx context.Context,
+	path string,
+	params *url.Values,
+	contentType string,
+	body io.Reader,
+) (*http.Response, error) {
+	request, err := client.newRequest(ctx, "PUT", path, params, body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create http request: %w", err)
+	}
+	request.Header.Set("Content-Type", contentType)
+	return client.do(request, true)
+}
+
+// Patch performs an authenticated PATCH request at the given path.
+// Includes params in the query string.
+// The provided ContentType should describe the content of the body.
+func (client *ApiClient) Patch(
+	path string,
+	params *url.Values,
+	contentType string,
+	body io.Reader,
+) (*http.Response, error) {
+	return client.PatchWithContext(context.Background(), path, params, contentType, body)
+}
+
+// PatchWithContext is like Patch but uses the given context for the request.
+func (client *ApiClient) PatchWithContext(
+	ctx context.Context,
+	path string,
+	params *url.Values,
+	contentType string,
+	body io.Reader,
+) (*http.Response, error) {
+	request, err := client.newRequest(ctx, "PATCH", path, params, body)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create http request: %w", err)
 	}
 	request.Header.Set("Content-Type", contentType)
 	return client.do(request, true)
 }
+
+// Delete performs an authenticated DELETE request at the given path.
+// Includes params in the query string.
+func (client *ApiClient) Delete(path string, params *url.Values) (*http.Response, error) {
+	return client.DeleteWithContext(context.Background(), path, params)
+}
+
+// DeleteWithContext is like Delete but uses the given context for the request.
+func (client *ApiClient) DeleteWithContext(
+	ctx context.Context,
+	path string,
+	params *url.Values,
+) (*http.Response, error) {
+	request, err := client.newRequest(ctx, "DELETE", path, params, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create http request: %w", err)
+	}
+	return client.do(request, true)
+}
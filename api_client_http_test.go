@@ -1,6 +1,7 @@
 package flareio
 
 import (
+	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
@@ -26,7 +27,7 @@ func newClientTest(
 
 	apiClient := NewApiClient(
 		"test-api-key",
-		withBaseUrl(httpServer.URL),
+		WithBaseUrl(httpServer.URL),
 	)
 	apiClient.apiToken = "test-api-token"
 	apiClient.apiTokenExp = time.Now().Add(time.Minute * 45)
@@ -193,3 +194,74 @@ func TestGetRetry429(t *testing.T) {
 
 	assert.Equal(t, 2, requestsReceived, "didn't perform the number of expected requests")
 }
+
+func TestRequestInterval(t *testing.T) {
+	requestsReceived := 0
+	ct := newClientTest(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requestsReceived = requestsReceived + 1
+		}),
+	)
+	defer ct.Close()
+
+	WithRequestInterval(time.Millisecond * 50)(ct.apiClient)
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		resp, err := ct.apiClient.Get("/some-path", nil)
+		if !assert.NoError(t, err, "failed to make get request") {
+			return
+		}
+		resp.Body.Close()
+	}
+
+	assert.Equal(t, 3, requestsReceived, "didn't perform the number of expected requests")
+	assert.GreaterOrEqual(
+		t,
+		time.Since(start),
+		time.Millisecond*100,
+		"requests should be spaced by the request interval",
+	)
+}
+
+func TestPutPatchDelete(t *testing.T) {
+	requests := []string{}
+	ct := newClientTest(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, err := io.ReadAll(r.Body)
+			if !assert.NoError(t, err, "failed to read request body") {
+				return
+			}
+			assert.Equal(t, "Bearer test-api-token", r.Header.Get("Authorization"))
+			requests = append(
+				requests,
+				fmt.Sprintf("%s %s %s %s", r.Method, r.URL.Path, r.Header.Get("Content-Type"), body),
+			)
+			w.WriteHeader(http.StatusNoContent)
+		}),
+	)
+	defer ct.Close()
+
+	resp, err := ct.apiClient.Put("/put", nil, "application/json", strings.NewReader(`"put"`))
+	if assert.NoError(t, err, "failed to make put request") {
+		resp.Body.Close()
+	}
+	resp, err = ct.apiClient.Patch("/patch", nil, "application/json", strings.NewReader(`"patch"`))
+	if assert.NoError(t, err, "failed to make patch request") {
+		resp.Body.Close()
+	}
+	resp, err = ct.apiClient.Delete("/delete", nil)
+	if assert.NoError(t, err, "failed to make delete request") {
+		resp.Body.Close()
+	}
+
+	assert.Equal(
+		t,
+		[]string{
+			`PUT /put application/json "put"`,
+			`PATCH /patch application/json "patch"`,
+			"DELETE /delete  ",
+		},
+		requests,
+	)
+}
@@ -0,0 +1,79 @@
+package flareio
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// ErrMissingScopes is matched by the errors returned when the API key
+// lacks scopes that are required.
+var ErrMissingScopes = errors.New("API key is missing required scopes")
+
+// Identity describes the API key used by the client.
+type Identity struct {
+	UserId           int64  `json:"user_id"`
+	UserEmail        string `json:"user_email"`
+	OrganizationId   int64  `json:"organization_id"`
+	OrganizationName string `json:"organization_name"`
+
+	// TenantId is the tenant that the requests are made for.
+	TenantId   int    `json:"tenant_id"`
+	TenantName string `json:"tenant_name"`
+
+	// Scopes are the permissions granted to the API key.
+	Scopes []string `json:"scopes"`
+}
+
+// HasScope returns whether the API key was granted the given scope.
+func (identity *Identity) HasScope(scope string) bool {
+	for _, granted := range identity.Scopes {
+		if granted == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// RequireScopes returns an error that lists the given scopes that
+// were not granted to the API key, if any.
+func (identity *Identity) RequireScopes(scopes ...string) error {
+	missing := []string{}
+	for _, scope := range scopes {
+		if !identity.HasScope(scope) {
+			missing = append(missing, scope)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("%w: %s", ErrMissingScopes, strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// WhoAmI describes the API key used by the client, which is useful to
+// validate its configuration at startup.
+func (client *ApiClient) WhoAmI(ctx context.Context) (*Identity, error) {
+	var identity Identity
+	if err := client.DoJson(ctx, http.MethodGet, "/tokens/test", nil, &identity); err != nil {
+		return nil, fmt.Errorf("failed to test token: %w", err)
+	}
+	return &identity, nil
+}
+
+// Tenant is a tenant that the API key can access.
+type Tenant struct {
+	Id   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+// ListTenants fetches the tenants that the API key can access. Any of
+// them can be used with WithTenantId.
+func (client *ApiClient) ListTenants(ctx context.Context) ([]*Tenant, error) {
+	tenants, err := ListAll[Tenant](ctx, client, "/firework/v2/me/tenants", nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tenants: %w", err)
+	}
+	return tenants, nil
+}
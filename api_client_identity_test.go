@@ -0,0 +1,88 @@
+package flareio
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWhoAmI(t *testing.T) {
+	ct := newClientTest(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/tokens/test", r.URL.Path)
+			w.Write([]byte(`{
+				"user_id": 12,
+				"user_email": "john@example.com",
+				"organization_id": 34,
+				"organization_name": "Example",
+				"tenant_id": 56,
+				"tenant_name": "Example Production",
+				"scopes": ["firework", "leaksdb"]
+			}`))
+		}),
+	)
+	defer ct.Close()
+
+	identity, err := ct.apiClient.WhoAmI(context.Background())
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, &Identity{
+		UserId:           12,
+		UserEmail:        "john@example.com",
+		OrganizationId:   34,
+		OrganizationName: "Example",
+		TenantId:         56,
+		TenantName:       "Example Production",
+		Scopes:           []string{"firework", "leaksdb"},
+	}, identity)
+
+	assert.True(t, identity.HasScope("leaksdb"))
+	assert.NoError(t, identity.RequireScopes("firework", "leaksdb"))
+
+	err = identity.RequireScopes("firework", "identifiers", "alerts")
+	assert.ErrorIs(t, err, ErrMissingScopes)
+	assert.EqualError(t, err, "API key is missing required scopes: identifiers, alerts")
+}
+
+func TestWhoAmIUnauthorized(t *testing.T) {
+	ct := newClientTest(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"message": "invalid token"}`))
+		}),
+	)
+	defer ct.Close()
+
+	_, err := ct.apiClient.WhoAmI(context.Background())
+	assert.EqualError(t, err, "failed to test token: got http status code 401: invalid token")
+}
+
+func TestListTenants(t *testing.T) {
+	ct := newClientTest(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/firework/v2/me/tenants", r.URL.Path)
+			if r.URL.Query().Get("from") == "" {
+				w.Write([]byte(`{"next": "second-page", "items": [{"id": 1, "name": "Production"}]}`))
+			} else {
+				w.Write([]byte(`{"next": null, "items": [{"id": 2, "name": "Staging"}]}`))
+			}
+		}),
+	)
+	defer ct.Close()
+
+	tenants, err := ct.apiClient.ListTenants(context.Background())
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(
+		t,
+		[]*Tenant{
+			{Id: 1, Name: "Production"},
+			{Id: 2, Name: "Staging"},
+		},
+		tenants,
+	)
+}
@@ -25,7 +25,7 @@ func TestCreateClientWithTenantId(t *testing.T) {
 func TestCreateClientWithBaseUrl(t *testing.T) {
 	c := NewApiClient(
 		"test-api-key",
-		withBaseUrl("https://test.com/"),
+		WithBaseUrl("https://test.com/"),
 	)
 	assert.Equal(t, "https://test.com/", c.baseUrl)
 }
@@ -3,86 +3,155 @@
 package flareio
 
 import (
-	"bytes"
+	"context"
 	"encoding/json"
-	"fmt"
-	"io"
+	"errors"
 	"iter"
-	"net/http"
 	"net/url"
 )
 
-// IterResult contains results for a given page.
-type IterResult struct {
-	// Response associated with the fetched page.
-	//
-	// The response's body must be closed.
-	Response *http.Response
+// errItemsStopped is returned to the pager when the consumer of an
+// item iterator stops early.
+var errItemsStopped = errors.New("items iteration stopped")
 
-	// Next is the token to be used to fetch the next page.
-	Next string
-}
-
-func getIterResult(
-	fetchPage func(from string) (*http.Response, error),
-	cursor string,
-) (*IterResult, error) {
-	response, err := fetchPage(cursor)
-	if err != nil {
-		return nil, fmt.Errorf("failed to fetch next page: %w", err)
-	}
-	defer response.Body.Close()
+func createPagingIterator(
+	fetchPage pageFetcher,
+	options *iterOptions,
+) iter.Seq2[*IterResult, error] {
+	return func(yield func(*IterResult, error) bool) {
+		// A new pager is created for each iteration so that ranging
+		// over the sequence again starts over.
+		pager := newPager(fetchPage, options)
+
+		// Fetching stops after each error, and resumes if the consumer
+		// chose to retry or skip the page that failed.
+		for pager.HasMore() {
+			pages := fetchPages(options.ctx, pager)
+			if options.prefetchDepth > 0 {
+				pages = prefetchPages(options.ctx, pager, options.prefetchDepth)
+			}
 
-	body, err := io.ReadAll(response.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read response: %w", err)
+			for iterResult, err := range pages {
+				if !yield(iterResult, err) {
+					return
+				}
+				if err != nil {
+					break
+				}
+				if err := options.saveCheckpoint(iterResult); err != nil {
+					yield(nil, err)
+					return
+				}
+			}
+		}
 	}
+}
 
-	if response.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf(
-			"got http status code %d status while fetching next page: %s",
-			response.StatusCode,
-			body,
-		)
+// fetchPages fetches the pages one after the other as they are
+// consumed, until an error occurs.
+func fetchPages(
+	ctx context.Context,
+	pager *Pager,
+) iter.Seq2[*IterResult, error] {
+	return func(yield func(*IterResult, error) bool) {
+		for !pager.done {
+			iterResult, err := pager.fetch(ctx, nil)
+			if !yield(iterResult, err) || err != nil {
+				return
+			}
+		}
 	}
+}
 
-	type ResponseWithNext struct {
-		Next string `json:"next"`
-	}
-	var responseWithNext ResponseWithNext
-	if err := json.Unmarshal(body, &responseWithNext); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
-	}
+type prefetchedPage struct {
+	iterResult *IterResult
+	err        error
+}
 
-	response.Body = io.NopCloser(bytes.NewReader(body))
+// prefetchPages fetches up to depth pages ahead of the consumer in a
+// background goroutine, until an error occurs. The pages that were not
+// consumed are released when the consumer stops.
+func prefetchPages(
+	ctx context.Context,
+	pager *Pager,
+	depth int,
+) iter.Seq2[*IterResult, error] {
+	return func(yield func(*IterResult, error) bool) {
+		ctx, cancel := context.WithCancel(ctx)
+
+		// stopped is closed when the consumer is gone. The context isn't
+		// used for that purpose so that errors caused by the cancellation
+		// of the parent context still reach the consumer.
+		stopped := make(chan struct{})
+
+		// The goroutine holds one fetched page while waiting to send it,
+		// so the channel only buffers the remaining ones.
+		prefetched := make(chan prefetchedPage, depth-1)
+		go func() {
+			defer close(prefetched)
+			for !pager.done {
+				iterResult, err := pager.fetch(ctx, nil)
+				select {
+				case prefetched <- prefetchedPage{iterResult: iterResult, err: err}:
+				case <-stopped:
+					if iterResult != nil {
+						iterResult.Response.Body.Close()
+					}
+					return
+				}
+				if err != nil {
+					return
+				}
+			}
+		}()
+
+		defer func() {
+			close(stopped)
+			cancel()
+			for page := range prefetched {
+				if page.iterResult != nil {
+					page.iterResult.Response.Body.Close()
+				}
+			}
+		}()
 
-	iterResult := &IterResult{
-		Response: response,
-		Next:     responseWithNext.Next,
+		for page := range prefetched {
+			if !yield(page.iterResult, page.err) {
+				return
+			}
+		}
 	}
-
-	return iterResult, nil
 }
 
-func createPagingIterator(
-	fetchPage func(from string) (*http.Response, error),
-) iter.Seq2[*IterResult, error] {
-	cursor := ""
-	return func(yield func(*IterResult, error) bool) {
-		for {
-			iterResult, err := getIterResult(
-				fetchPage,
-				cursor,
+func createItemIterator(
+	fetchPage pageFetcher,
+	options *iterOptions,
+) iter.Seq2[json.RawMessage, error] {
+	return func(yield func(json.RawMessage, error) bool) {
+		pager := newPager(fetchPage, options)
+		for pager.HasMore() {
+			iterResult, err := pager.fetch(
+				options.ctx,
+				func(item json.RawMessage) error {
+					if !yield(item, nil) {
+						return errItemsStopped
+					}
+					return nil
+				},
 			)
-			if err != nil {
-				yield(nil, err)
+			if errors.Is(err, errItemsStopped) {
 				return
 			}
-			cursor = iterResult.Next
-			if !yield(iterResult, err) {
-				return
+			if err != nil {
+				// The pager resumes if the consumer chose to retry
+				// or skip the page that failed.
+				if !yield(nil, err) {
+					return
+				}
+				continue
 			}
-			if cursor == "" {
+			if err := options.saveCheckpoint(iterResult); err != nil {
+				yield(nil, err)
 				return
 			}
 		}
@@ -91,54 +160,74 @@ func createPagingIterator(
 
 // IterGet allows to iterate over responses for an API endpoint that
 // supports the Flare standard paging pattern.
+//
+// The params are copied for every page and are never modified.
 func (client *ApiClient) IterGet(
 	path string,
 	params *url.Values,
+	optionFns ...IterOption,
 ) iter.Seq2[*IterResult, error] {
+	options := newIterOptions(optionFns)
 	return createPagingIterator(
-		func(cursor string) (*http.Response, error) {
-			if cursor != "" {
-				if params == nil {
-					params = &url.Values{}
-				}
-				params.Set("from", cursor)
-			}
-			return client.Get(
-				path,
-				params,
-			)
-		},
+		client.getPageFetcher(path, params, options.pagingStrategy),
+		options,
 	)
 }
 
 // IterPostJson allows to iterate over responses for an API endpoint that
 // supports the Flare standard paging pattern.
+//
+// The body can be any value that encodes to a JSON object, such as a
+// map, a struct or a json.RawMessage. It is encoded when the iterator
+// is created, and the cursor is added to the encoded object. The params
+// and body are never modified.
 func (client *ApiClient) IterPostJson(
 	path string,
 	params *url.Values,
-	body map[string]interface{},
+	body interface{},
+	optionFns ...IterOption,
 ) iter.Seq2[*IterResult, error] {
+	options := newIterOptions(optionFns)
 	return createPagingIterator(
-		func(cursor string) (*http.Response, error) {
-			if cursor != "" {
-				if body == nil {
-					body = make(map[string]interface{})
-				}
-				body["from"] = cursor
-			}
-
-			encodedJson, err := json.Marshal(body)
-			if err != nil {
-				return nil, fmt.Errorf("failed to marshal body to JSON: %w", err)
-			}
+		client.postJsonPageFetcher(path, params, body, options.pagingStrategy),
+		options,
+	)
+}
 
-			return client.Post(
-				path,
-				params,
-				"application/json",
-				bytes.NewReader(encodedJson),
-			)
-		},
+// IterGetItems allows to iterate over the items of an API endpoint that
+// supports the Flare standard paging pattern.
+//
+// Pages are decoded as they are received and each element of their
+// items array is yielded as soon as it is decoded, so pages are never
+// held in memory. Prefetching is not supported.
+func (client *ApiClient) IterGetItems(
+	path string,
+	params *url.Values,
+	optionFns ...IterOption,
+) iter.Seq2[json.RawMessage, error] {
+	options := newIterOptions(optionFns)
+	return createItemIterator(
+		client.getPageFetcher(path, params, options.pagingStrategy),
+		options,
 	)
+}
 
+// IterPostJsonItems allows to iterate over the items of an API endpoint
+// that supports the Flare standard paging pattern.
+//
+// Pages are decoded as they are received and each element of their
+// items array is yielded as soon as it is decoded, so pages are never
+// held in memory. Prefetching is not supported. The body is handled
+// like in IterPostJson.
+func (client *ApiClient) IterPostJsonItems(
+	path string,
+	params *url.Values,
+	body interface{},
+	optionFns ...IterOption,
+) iter.Seq2[json.RawMessage, error] {
+	options := newIterOptions(optionFns)
+	return createItemIterator(
+		client.postJsonPageFetcher(path, params, body, options.pagingStrategy),
+		options,
+	)
 }
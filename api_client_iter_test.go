@@ -3,10 +3,14 @@
 package flareio
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/url"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -209,3 +213,1116 @@ func TestIterPostJsonNilMap(t *testing.T) {
 
 	assert.Equal(t, 2, requestsSent, "Didn't get the expected number of pages")
 }
+
+func TestIterGetStartCursor(t *testing.T) {
+	ct := newClientTest(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			cursor := r.URL.Query().Get("from")
+			if cursor == "second-page" {
+				w.Write([]byte(`{"next":"third-page", "items": []}`))
+			} else {
+				w.Write([]byte(`{"next": null, "items": []}`))
+			}
+		}),
+	)
+	defer ct.Close()
+
+	cursors := []string{}
+	for result, err := range ct.apiClient.IterGet(
+		"/leaksdb/sources",
+		nil,
+		WithStartCursor("second-page"),
+	) {
+		if len(cursors) > 5 {
+			// We are going crazy here...
+			break
+		}
+		if !assert.NoError(t, err, "iter yielded an error") {
+			break
+		}
+		cursors = append(cursors, result.Cursor)
+	}
+
+	assert.Equal(
+		t,
+		[]string{"second-page", "third-page"},
+		cursors,
+		"Didn't get the expected page cursors",
+	)
+}
+
+func TestIterGetCheckpointer(t *testing.T) {
+	requestedCursors := []string{}
+	ct := newClientTest(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			cursor := r.URL.Query().Get("from")
+			requestedCursors = append(requestedCursors, cursor)
+			if cursor == "" {
+				w.Write([]byte(`{"next":"second-page", "items": []}`))
+			} else if cursor == "second-page" {
+				w.Write([]byte(`{"next":"third-page", "items": []}`))
+			} else {
+				w.Write([]byte(`{"next": null, "items": []}`))
+			}
+		}),
+	)
+	defer ct.Close()
+
+	checkpointer := NewFileCheckpointer(
+		filepath.Join(t.TempDir(), "checkpoint"),
+	)
+
+	// Stop after the first page, as if the job had died.
+	for result, err := range ct.apiClient.IterGet(
+		"/leaksdb/sources",
+		nil,
+		WithCheckpointer(checkpointer),
+	) {
+		assert.NoError(t, err, "iter yielded an error")
+		assert.Equal(t, "second-page", result.Next)
+		break
+	}
+
+	cursor, err := checkpointer.LoadCursor()
+	if !assert.NoError(t, err, "failed to load checkpoint") {
+		return
+	}
+	assert.Equal(t, "", cursor, "a page that wasn't processed should not be checkpointed")
+
+	// Process the first page completely before stopping.
+	pagesProcessed := 0
+	for _, err := range ct.apiClient.IterGet(
+		"/leaksdb/sources",
+		nil,
+		WithCheckpointer(checkpointer),
+	) {
+		assert.NoError(t, err, "iter yielded an error")
+		if pagesProcessed == 1 {
+			break
+		}
+		pagesProcessed = pagesProcessed + 1
+	}
+
+	cursor, err = checkpointer.LoadCursor()
+	if !assert.NoError(t, err, "failed to load checkpoint") {
+		return
+	}
+	assert.Equal(t, "second-page", cursor)
+
+	// Resume from the checkpoint.
+	requestedCursors = []string{}
+	for _, err := range ct.apiClient.IterGet(
+		"/leaksdb/sources",
+		nil,
+		WithCheckpointer(checkpointer),
+	) {
+		assert.NoError(t, err, "iter yielded an error")
+		if len(requestedCursors) > 5 {
+			// We are going crazy here...
+			break
+		}
+	}
+
+	assert.Equal(
+		t,
+		[]string{"second-page", "third-page"},
+		requestedCursors,
+		"Didn't resume from the checkpoint",
+	)
+
+	cursor, err = checkpointer.LoadCursor()
+	if !assert.NoError(t, err, "failed to load checkpoint") {
+		return
+	}
+	assert.Equal(t, checkpointCompleted, cursor, "the iteration should be marked as completed")
+
+	// Resuming a completed iteration fetches nothing.
+	requestedCursors = []string{}
+	for _, err := range ct.apiClient.IterGet(
+		"/leaksdb/sources",
+		nil,
+		WithCheckpointer(checkpointer),
+	) {
+		assert.NoError(t, err, "iter yielded an error")
+		break
+	}
+	assert.Empty(t, requestedCursors, "a completed iteration should not fetch pages again")
+
+	// Clearing the checkpoint starts over.
+	if !assert.NoError(t, checkpointer.Clear()) {
+		return
+	}
+	for _, err := range ct.apiClient.IterGet(
+		"/leaksdb/sources",
+		nil,
+		WithCheckpointer(checkpointer),
+	) {
+		assert.NoError(t, err, "iter yielded an error")
+		break
+	}
+	assert.Equal(t, []string{""}, requestedCursors, "a cleared iteration should start over")
+}
+
+func TestIterGetDoesNotMutateParams(t *testing.T) {
+	ct := newClientTest(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "value1", r.URL.Query().Get("param1"))
+			if r.URL.Query().Get("from") == "" {
+				w.Write([]byte(`{"next":"second-page", "items": []}`))
+			} else {
+				w.Write([]byte(`{"next": null, "items": []}`))
+			}
+		}),
+	)
+	defer ct.Close()
+
+	params := &url.Values{
+		"param1": []string{"value1"},
+	}
+
+	for range 2 {
+		pagesFetched := 0
+		for _, err := range ct.apiClient.IterGet(
+			"/leaksdb/sources",
+			params,
+		) {
+			pagesFetched = pagesFetched + 1
+			if pagesFetched > 5 {
+				// We are going crazy here...
+				break
+			}
+			assert.NoError(t, err, "iter yielded an error")
+		}
+		assert.Equal(t, 2, pagesFetched, "reusing params should start from the first page")
+	}
+
+	assert.Equal(
+		t,
+		&url.Values{
+			"param1": []string{"value1"},
+		},
+		params,
+		"params should not be modified",
+	)
+}
+
+func TestIterPostJsonDoesNotMutateBody(t *testing.T) {
+	ct := newClientTest(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			type PagedRequest struct {
+				From string `json:"from"`
+			}
+			var pagedRequest PagedRequest
+			if err := json.NewDecoder(r.Body).Decode(&pagedRequest); !assert.NoError(t, err, "Error decoding posted JSON") {
+				return
+			}
+			if pagedRequest.From == "" {
+				w.Write([]byte(`{"next":"second-page", "items": []}`))
+			} else {
+				w.Write([]byte(`{"next": null, "items": []}`))
+			}
+		}),
+	)
+	defer ct.Close()
+
+	body := map[string]interface{}{
+		"some_param": "hello",
+	}
+
+	for range 2 {
+		pagesFetched := 0
+		for _, err := range ct.apiClient.IterPostJson(
+			"/leaksdb/sources",
+			nil,
+			body,
+		) {
+			pagesFetched = pagesFetched + 1
+			if pagesFetched > 5 {
+				// We are going crazy here...
+				break
+			}
+			assert.NoError(t, err, "iter yielded an error")
+		}
+		assert.Equal(t, 2, pagesFetched, "reusing the body should start from the first page")
+	}
+
+	assert.Equal(
+		t,
+		map[string]interface{}{
+			"some_param": "hello",
+		},
+		body,
+		"body should not be modified",
+	)
+}
+
+func TestIterGetReiterate(t *testing.T) {
+	requestedCursors := []string{}
+	ct := newClientTest(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			cursor := r.URL.Query().Get("from")
+			requestedCursors = append(requestedCursors, cursor)
+			if cursor == "" {
+				w.Write([]byte(`{"next":"second-page", "items": []}`))
+			} else {
+				w.Write([]byte(`{"next": null, "items": []}`))
+			}
+		}),
+	)
+	defer ct.Close()
+
+	pages := ct.apiClient.IterGet("/leaksdb/sources", nil)
+
+	for range 2 {
+		pagesFetched := 0
+		for _, err := range pages {
+			pagesFetched = pagesFetched + 1
+			if pagesFetched > 5 {
+				// We are going crazy here...
+				break
+			}
+			assert.NoError(t, err, "iter yielded an error")
+		}
+	}
+
+	assert.Equal(
+		t,
+		[]string{"", "second-page", "", "second-page"},
+		requestedCursors,
+		"ranging over the sequence again should start over",
+	)
+}
+
+func TestIterGetPrefetch(t *testing.T) {
+	secondPageRequested := make(chan struct{})
+	ct := newClientTest(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			cursor := r.URL.Query().Get("from")
+			if cursor == "" {
+				w.Write([]byte(`{"next":"second-page", "items": []}`))
+			} else if cursor == "second-page" {
+				close(secondPageRequested)
+				w.Write([]byte(`{"next":"third-page", "items": []}`))
+			} else {
+				w.Write([]byte(`{"next": null, "items": []}`))
+			}
+		}),
+	)
+	defer ct.Close()
+
+	cursors := []string{}
+	for result, err := range ct.apiClient.IterGet(
+		"/leaksdb/sources",
+		nil,
+		WithPrefetch(2),
+	) {
+		if len(cursors) > 5 {
+			// We are going crazy here...
+			break
+		}
+		if !assert.NoError(t, err, "iter yielded an error") {
+			break
+		}
+		if result.Cursor == "" {
+			select {
+			case <-secondPageRequested:
+			case <-time.After(time.Second * 5):
+				assert.Fail(t, "the second page wasn't prefetched")
+			}
+		}
+		cursors = append(cursors, result.Cursor)
+	}
+
+	assert.Equal(
+		t,
+		[]string{"", "second-page", "third-page"},
+		cursors,
+		"pages should be yielded in order",
+	)
+}
+
+func TestIterGetPrefetchEarlyBreak(t *testing.T) {
+	ct := newClientTest(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			// Every page has a next page.
+			w.Write([]byte(`{"next":"another-page", "items": []}`))
+		}),
+	)
+	defer ct.Close()
+
+	pagesFetched := 0
+	for _, err := range ct.apiClient.IterGet(
+		"/leaksdb/sources",
+		nil,
+		WithPrefetch(3),
+	) {
+		assert.NoError(t, err, "iter yielded an error")
+		pagesFetched = pagesFetched + 1
+		if pagesFetched == 2 {
+			break
+		}
+	}
+
+	assert.Equal(t, 2, pagesFetched, "the iteration should stop when the consumer breaks")
+}
+
+func TestIterGetContextCanceled(t *testing.T) {
+	ct := newClientTest(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			cursor := r.URL.Query().Get("from")
+			w.Write([]byte(`{"next":"after-` + cursor + `", "items": []}`))
+		}),
+	)
+	defer ct.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	pagesFetched := 0
+	var lastErr error
+	for _, err := range ct.apiClient.IterGet(
+		"/leaksdb/sources",
+		nil,
+		WithContext(ctx),
+		WithPrefetch(1),
+	) {
+		pagesFetched = pagesFetched + 1
+		if pagesFetched > 5 {
+			// We are going crazy here...
+			break
+		}
+		lastErr = err
+		cancel()
+	}
+
+	assert.ErrorIs(t, lastErr, context.Canceled, "the iteration should end with the context error")
+}
+
+func newEndlessPagingTest(t *testing.T) *clientTest {
+	return newClientTest(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/leaksdb/sources", r.URL.Path)
+			cursor := r.URL.Query().Get("from")
+			w.Write([]byte(`{"next":"after-` + cursor + `", "items": [1, 2]}`))
+		}),
+	)
+}
+
+func TestIterGetMaxPages(t *testing.T) {
+	ct := newEndlessPagingTest(t)
+	defer ct.Close()
+
+	pagesFetched := 0
+	var lastErr error
+	for result, err := range ct.apiClient.IterGet(
+		"/leaksdb/sources",
+		nil,
+		WithMaxPages(3),
+	) {
+		if err != nil {
+			lastErr = err
+			break
+		}
+		assert.NotNil(t, result, "didn't get a result")
+		pagesFetched = pagesFetched + 1
+	}
+
+	assert.Equal(t, 3, pagesFetched, "Didn't get the expected number of pages")
+	assert.ErrorIs(t, lastErr, ErrPagingLimitReached)
+
+	var limitErr *PagingLimitError
+	if assert.ErrorAs(t, lastErr, &limitErr) {
+		assert.Equal(t, "after-after-after-", limitErr.Cursor, "the error should contain the resume cursor")
+	}
+}
+
+func TestIterGetMaxItems(t *testing.T) {
+	ct := newEndlessPagingTest(t)
+	defer ct.Close()
+
+	pagesFetched := 0
+	var lastErr error
+	for _, err := range ct.apiClient.IterGet(
+		"/leaksdb/sources",
+		nil,
+		WithMaxItems(3),
+	) {
+		if err != nil {
+			lastErr = err
+			break
+		}
+		pagesFetched = pagesFetched + 1
+	}
+
+	assert.Equal(t, 2, pagesFetched, "pages should not be split to honor the limit")
+	assert.ErrorIs(t, lastErr, ErrPagingLimitReached)
+}
+
+func TestIterGetMaxDuration(t *testing.T) {
+	ct := newEndlessPagingTest(t)
+	defer ct.Close()
+
+	// Each page takes a minute to process according to the clock.
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	withTestClock := func(options *iterOptions) {
+		options.now = func() time.Time {
+			return now
+		}
+	}
+
+	pagesFetched := 0
+	var lastErr error
+	for _, err := range ct.apiClient.IterGet(
+		"/leaksdb/sources",
+		nil,
+		WithMaxDuration(time.Second*90),
+		withTestClock,
+	) {
+		if err != nil {
+			lastErr = err
+			break
+		}
+		pagesFetched = pagesFetched + 1
+		now = now.Add(time.Minute)
+	}
+
+	assert.Equal(t, 2, pagesFetched, "Didn't get the expected number of pages")
+	assert.ErrorIs(t, lastErr, ErrPagingLimitReached)
+}
+
+func TestIterGetMaxPagesOnLastPage(t *testing.T) {
+	ct := newClientTest(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Query().Get("from") == "" {
+				w.Write([]byte(`{"next":"second-page", "items": []}`))
+			} else {
+				w.Write([]byte(`{"next": null, "items": []}`))
+			}
+		}),
+	)
+	defer ct.Close()
+
+	pagesFetched := 0
+	for _, err := range ct.apiClient.IterGet(
+		"/leaksdb/sources",
+		nil,
+		WithMaxPages(2),
+	) {
+		assert.NoError(t, err, "reaching the limit on the last page should not be an error")
+		pagesFetched = pagesFetched + 1
+	}
+
+	assert.Equal(t, 2, pagesFetched, "Didn't get the expected number of pages")
+}
+
+func TestIterGetPageRetry(t *testing.T) {
+	requestsReceived := 0
+	ct := newClientTest(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requestsReceived = requestsReceived + 1
+			if requestsReceived < 3 {
+				// Truncated body, which isn't retried by the http client.
+				w.Write([]byte(`{"next": "sec`))
+			} else {
+				w.Write([]byte(`{"next": null, "items": []}`))
+			}
+		}),
+	)
+	defer ct.Close()
+
+	pagesFetched := 0
+	for _, err := range ct.apiClient.IterGet(
+		"/leaksdb/sources",
+		nil,
+		WithPageRetry(PageRetryPolicy{
+			MaxRetries: 2,
+			WaitMin:    time.Millisecond,
+		}),
+	) {
+		assert.NoError(t, err, "the page should have been retried")
+		pagesFetched = pagesFetched + 1
+	}
+
+	assert.Equal(t, 1, pagesFetched, "Didn't get the expected number of pages")
+	assert.Equal(t, 3, requestsReceived, "didn't perform the number of expected requests")
+}
+
+func TestIterGetPageRetryExhausted(t *testing.T) {
+	requestsReceived := 0
+	ct := newClientTest(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requestsReceived = requestsReceived + 1
+			w.Write([]byte(`{"next": "sec`))
+		}),
+	)
+	defer ct.Close()
+
+	var lastErr error
+	for _, err := range ct.apiClient.IterGet(
+		"/leaksdb/sources",
+		nil,
+		WithPageRetry(PageRetryPolicy{
+			MaxRetries: 1,
+			WaitMin:    time.Millisecond,
+		}),
+	) {
+		lastErr = err
+	}
+
+	var pageErr *PageError
+	if assert.ErrorAs(t, lastErr, &pageErr) {
+		assert.Equal(t, 2, pageErr.Attempts)
+		assert.Equal(t, "", pageErr.Cursor)
+	}
+	assert.Equal(t, 2, requestsReceived, "didn't perform the number of expected requests")
+}
+
+func TestIterGetPageRetryClientError(t *testing.T) {
+	requestsReceived := 0
+	ct := newClientTest(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requestsReceived = requestsReceived + 1
+			w.WriteHeader(http.StatusBadRequest)
+		}),
+	)
+	defer ct.Close()
+
+	for _, err := range ct.apiClient.IterGet(
+		"/leaksdb/sources",
+		nil,
+		WithPageRetry(PageRetryPolicy{
+			MaxRetries: 3,
+			WaitMin:    time.Millisecond,
+		}),
+	) {
+		assert.ErrorContains(t, err, "got http status code 400")
+	}
+
+	assert.Equal(t, 1, requestsReceived, "client errors should not be retried")
+}
+
+func TestIterGetConsumerRetry(t *testing.T) {
+	requestsReceived := 0
+	ct := newClientTest(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requestsReceived = requestsReceived + 1
+			if requestsReceived == 1 {
+				w.Write([]byte(`{"next": "sec`))
+			} else {
+				w.Write([]byte(`{"next": null, "items": []}`))
+			}
+		}),
+	)
+	defer ct.Close()
+
+	errorsYielded := 0
+	pagesFetched := 0
+	for result, err := range ct.apiClient.IterGet(
+		"/leaksdb/sources",
+		nil,
+		WithPrefetch(2),
+	) {
+		if requestsReceived > 5 {
+			// We are going crazy here...
+			break
+		}
+		var pageErr *PageError
+		if errors.As(err, &pageErr) {
+			errorsYielded = errorsYielded + 1
+			pageErr.Retry()
+			continue
+		}
+		assert.NoError(t, err, "iter yielded an error")
+		assert.NotNil(t, result, "didn't get a result")
+		pagesFetched = pagesFetched + 1
+	}
+
+	assert.Equal(t, 1, errorsYielded, "Didn't get the expected number of errors")
+	assert.Equal(t, 1, pagesFetched, "Didn't get the expected number of pages")
+}
+
+func TestIterGetConsumerSkip(t *testing.T) {
+	ct := newClientTest(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			cursor := r.URL.Query().Get("from")
+			if cursor == "" {
+				// Truncated page, but the next token can still be decoded.
+				w.Write([]byte(`{"next": "second-page", "items": [1, `))
+			} else {
+				w.Write([]byte(`{"next": null, "items": []}`))
+			}
+		}),
+	)
+	defer ct.Close()
+
+	cursors := []string{}
+	for result, err := range ct.apiClient.IterGet(
+		"/leaksdb/sources",
+		nil,
+	) {
+		if len(cursors) > 5 {
+			// We are going crazy here...
+			break
+		}
+		var pageErr *PageError
+		if errors.As(err, &pageErr) {
+			assert.True(t, pageErr.Skip(), "the page should be skippable")
+			continue
+		}
+		if !assert.NoError(t, err, "iter yielded an error") {
+			break
+		}
+		cursors = append(cursors, result.Cursor)
+	}
+
+	assert.Equal(t, []string{"second-page"}, cursors, "the failed page should be skipped")
+}
+
+func TestIterGetItemsNotArray(t *testing.T) {
+	ct := newClientTest(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Query().Get("from") == "" {
+				w.Write([]byte(`{"next": "second-page", "items": {"count": 5}}`))
+			} else {
+				w.Write([]byte(`{"next": null, "items": 5}`))
+			}
+		}),
+	)
+	defer ct.Close()
+
+	for _, optionFns := range [][]IterOption{
+		nil,
+		{WithMaxItems(10)},
+	} {
+		pagesFetched := 0
+		for _, err := range ct.apiClient.IterGet(
+			"/leaksdb/sources",
+			nil,
+			optionFns...,
+		) {
+			if !assert.NoError(t, err, "items that are not an array should be accepted") {
+				break
+			}
+			pagesFetched = pagesFetched + 1
+		}
+		assert.Equal(t, 2, pagesFetched, "Didn't get the expected number of pages")
+	}
+}
+
+func TestIterGetCursorLoop(t *testing.T) {
+	ct := newClientTest(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			cursor := r.URL.Query().Get("from")
+			if cursor == "" {
+				w.Write([]byte(`{"next":"second-page", "items": [1]}`))
+			} else {
+				// The server keeps sending the same cursor.
+				w.Write([]byte(`{"next":"second-page", "items": [1]}`))
+			}
+		}),
+	)
+	defer ct.Close()
+
+	pagesFetched := 0
+	var lastErr error
+	for _, err := range ct.apiClient.IterGet(
+		"/leaksdb/sources",
+		nil,
+	) {
+		if pagesFetched > 5 {
+			// We are going crazy here...
+			break
+		}
+		if err != nil {
+			lastErr = err
+			break
+		}
+		pagesFetched = pagesFetched + 1
+	}
+
+	assert.Equal(t, 2, pagesFetched, "Didn't get the expected number of pages")
+	assert.ErrorIs(t, lastErr, ErrCursorLoop)
+
+	var loopErr *CursorLoopError
+	if assert.ErrorAs(t, lastErr, &loopErr) {
+		assert.Equal(t, "second-page", loopErr.Cursor)
+	}
+}
+
+func TestIterGetCursorCycle(t *testing.T) {
+	ct := newClientTest(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			cursor := r.URL.Query().Get("from")
+			if cursor == "a" {
+				w.Write([]byte(`{"next":"b", "items": [1]}`))
+			} else if cursor == "b" {
+				w.Write([]byte(`{"next":"c", "items": [1]}`))
+			} else {
+				w.Write([]byte(`{"next":"a", "items": [1]}`))
+			}
+		}),
+	)
+	defer ct.Close()
+
+	cursors := []string{}
+	var lastErr error
+	for result, err := range ct.apiClient.IterGet(
+		"/leaksdb/sources",
+		nil,
+	) {
+		if len(cursors) > 5 {
+			// We are going crazy here...
+			break
+		}
+		if err != nil {
+			lastErr = err
+			break
+		}
+		cursors = append(cursors, result.Cursor)
+	}
+
+	assert.Equal(t, []string{"", "a", "b", "c"}, cursors)
+	assert.ErrorIs(t, lastErr, ErrCursorLoop)
+}
+
+func TestIterGetMaxEmptyPages(t *testing.T) {
+	ct := newClientTest(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			cursor := r.URL.Query().Get("from")
+			if cursor == "" {
+				w.Write([]byte(`{"next":"after-", "items": [1]}`))
+			} else {
+				w.Write([]byte(`{"next":"after-` + cursor + `", "items": []}`))
+			}
+		}),
+	)
+	defer ct.Close()
+
+	pagesFetched := 0
+	var lastErr error
+	for _, err := range ct.apiClient.IterGet(
+		"/leaksdb/sources",
+		nil,
+		WithMaxEmptyPages(2),
+	) {
+		if pagesFetched > 10 {
+			// We are going crazy here...
+			break
+		}
+		if err != nil {
+			lastErr = err
+			break
+		}
+		pagesFetched = pagesFetched + 1
+	}
+
+	assert.Equal(t, 4, pagesFetched, "Didn't get the expected number of pages")
+	assert.ErrorIs(t, lastErr, ErrEmptyPages)
+}
+
+func TestIterGetItems(t *testing.T) {
+	ct := newClientTest(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			cursor := r.URL.Query().Get("from")
+			if cursor == "" {
+				w.Write([]byte(`{"items": [{"id": 1}, {"id": 2}], "next": "second-page"}`))
+			} else if cursor == "second-page" {
+				w.Write([]byte(`{"next": "third-page", "items": []}`))
+			} else {
+				w.Write([]byte(`{"items": [{"id": 3}], "next": null}`))
+			}
+		}),
+	)
+	defer ct.Close()
+
+	ids := []int{}
+	for item, err := range ct.apiClient.IterGetItems(
+		"/leaksdb/sources",
+		nil,
+	) {
+		if len(ids) > 5 {
+			// We are going crazy here...
+			break
+		}
+		if !assert.NoError(t, err, "iter yielded an error") {
+			break
+		}
+		type Item struct {
+			Id int `json:"id"`
+		}
+		var decoded Item
+		if !assert.NoError(t, json.Unmarshal(item, &decoded)) {
+			break
+		}
+		ids = append(ids, decoded.Id)
+	}
+
+	assert.Equal(t, []int{1, 2, 3}, ids)
+}
+
+func TestIterPostJsonItemsEarlyBreak(t *testing.T) {
+	requestsReceived := 0
+	ct := newClientTest(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requestsReceived = requestsReceived + 1
+			w.Write([]byte(`{"items": [1, 2, 3], "next": "another-page"}`))
+		}),
+	)
+	defer ct.Close()
+
+	itemsReceived := 0
+	for _, err := range ct.apiClient.IterPostJsonItems(
+		"/leaksdb/sources",
+		nil,
+		nil,
+	) {
+		assert.NoError(t, err, "iter yielded an error")
+		itemsReceived = itemsReceived + 1
+		if itemsReceived == 2 {
+			break
+		}
+	}
+
+	assert.Equal(t, 2, itemsReceived)
+	assert.Equal(t, 1, requestsReceived, "no other page should be fetched after a break")
+}
+
+func TestIterGetItemsPartialPageNotRetried(t *testing.T) {
+	requestsReceived := 0
+	ct := newClientTest(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requestsReceived = requestsReceived + 1
+			w.Write([]byte(`{"items": [1, 2, `))
+		}),
+	)
+	defer ct.Close()
+
+	items := []string{}
+	var lastErr error
+	for item, err := range ct.apiClient.IterGetItems(
+		"/leaksdb/sources",
+		nil,
+		WithPageRetry(PageRetryPolicy{
+			MaxRetries: 3,
+			WaitMin:    time.Millisecond,
+		}),
+	) {
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		items = append(items, string(item))
+	}
+
+	assert.Equal(t, []string{"1", "2"}, items)
+	assert.Equal(t, 1, requestsReceived, "partially streamed pages should not be retried")
+
+	var pageErr *PageError
+	assert.ErrorAs(t, lastErr, &pageErr)
+}
+
+func TestIterGetNestedCursorPaging(t *testing.T) {
+	ct := newClientTest(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Query().Get("cursor") == "" {
+				w.Write([]byte(`{"items": [1], "meta": {"next_cursor": "second-page"}}`))
+			} else {
+				w.Write([]byte(`{"items": [2], "meta": {"next_cursor": null}}`))
+			}
+		}),
+	)
+	defer ct.Close()
+
+	cursors := []string{}
+	for result, err := range ct.apiClient.IterGet(
+		"/some-path",
+		nil,
+		WithPagingStrategy(CursorPaging{
+			NextPath: []string{"meta", "next_cursor"},
+			FromKey:  "cursor",
+		}),
+	) {
+		if len(cursors) > 5 {
+			// We are going crazy here...
+			break
+		}
+		if !assert.NoError(t, err, "iter yielded an error") {
+			break
+		}
+		cursors = append(cursors, result.Cursor)
+	}
+
+	assert.Equal(t, []string{"", "second-page"}, cursors)
+}
+
+func newPostedBodiesTest(t *testing.T, postedBodies *[]map[string]interface{}) *clientTest {
+	return newClientTest(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var postedBody map[string]interface{}
+			if err := json.NewDecoder(r.Body).Decode(&postedBody); !assert.NoError(t, err, "Error decoding posted JSON") {
+				return
+			}
+			*postedBodies = append(*postedBodies, postedBody)
+			if postedBody["from"] == nil || postedBody["from"] == "stale-cursor" {
+				w.Write([]byte(`{"next":"second-page", "items": []}`))
+			} else {
+				w.Write([]byte(`{"next": null, "items": []}`))
+			}
+		}),
+	)
+}
+
+func TestIterPostJsonStructBody(t *testing.T) {
+	postedBodies := []map[string]interface{}{}
+	ct := newPostedBodiesTest(t, &postedBodies)
+	defer ct.Close()
+
+	type Query struct {
+		Type string `json:"type"`
+		Fqdn string `json:"fqdn"`
+	}
+	type SearchBody struct {
+		Query Query  `json:"query"`
+		From  string `json:"from,omitempty"`
+	}
+	body := &SearchBody{
+		Query: Query{Type: "domain", Fqdn: "example.com"},
+		From:  "stale-cursor",
+	}
+
+	for _, err := range ct.apiClient.IterPostJson("/some-path", nil, body) {
+		if len(postedBodies) > 5 {
+			// We are going crazy here...
+			break
+		}
+		assert.NoError(t, err, "iter yielded an error")
+	}
+
+	query := map[string]interface{}{"type": "domain", "fqdn": "example.com"}
+	assert.Equal(
+		t,
+		[]map[string]interface{}{
+			{"query": query, "from": "stale-cursor"},
+			{"query": query, "from": "second-page"},
+		},
+		postedBodies,
+		"the cursor should override the from field",
+	)
+	assert.Equal(t, "stale-cursor", body.From, "the body should not be modified")
+}
+
+func TestIterPostJsonRawMessageBody(t *testing.T) {
+	postedBodies := []map[string]interface{}{}
+	ct := newPostedBodiesTest(t, &postedBodies)
+	defer ct.Close()
+
+	for _, err := range ct.apiClient.IterPostJson(
+		"/some-path",
+		nil,
+		json.RawMessage(`{"size": 10}`),
+	) {
+		if len(postedBodies) > 5 {
+			// We are going crazy here...
+			break
+		}
+		assert.NoError(t, err, "iter yielded an error")
+	}
+
+	assert.Equal(
+		t,
+		[]map[string]interface{}{
+			{"size": float64(10)},
+			{"size": float64(10), "from": "second-page"},
+		},
+		postedBodies,
+	)
+}
+
+func TestIterPostJsonNotAnObject(t *testing.T) {
+	requestsReceived := 0
+	ct := newClientTest(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requestsReceived = requestsReceived + 1
+		}),
+	)
+	defer ct.Close()
+
+	errorsYielded := 0
+	for _, err := range ct.apiClient.IterPostJson(
+		"/some-path",
+		nil,
+		[]string{"not", "an", "object"},
+		WithPageRetry(PageRetryPolicy{
+			MaxRetries: 3,
+			WaitMin:    time.Millisecond,
+		}),
+	) {
+		errorsYielded = errorsYielded + 1
+		assert.ErrorContains(t, err, "body must be a JSON object")
+	}
+
+	assert.Equal(t, 1, errorsYielded, "Didn't get the expected number of errors")
+	assert.Equal(t, 0, requestsReceived, "invalid bodies should not be sent")
+}
+
+func TestIterGetItemsFollow(t *testing.T) {
+	requestedCursors := []string{}
+	ct := newClientTest(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			cursor := r.URL.Query().Get("from")
+			requestedCursors = append(requestedCursors, cursor)
+			switch len(requestedCursors) {
+			case 1:
+				w.Write([]byte(`{"next": "second-page", "items": [1, 2]}`))
+			case 2:
+				// Nothing new yet.
+				w.Write([]byte(`{"next": "second-page", "items": []}`))
+			case 3:
+				w.Write([]byte(`{"next": `))
+			default:
+				w.Write([]byte(`{"next": null, "items": [3]}`))
+			}
+		}),
+	)
+	defer ct.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	items := []string{}
+	errs := []error{}
+	for item, err := range ct.apiClient.IterGetItems(
+		"/leaksdb/sources",
+		nil,
+		WithContext(ctx),
+		WithFollow(FollowPolicy{
+			Interval: time.Millisecond,
+		}),
+	) {
+		if len(requestedCursors) > 10 {
+			// We are going crazy here...
+			break
+		}
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		items = append(items, string(item))
+		if len(items) == 3 {
+			cancel()
+		}
+	}
+
+	assert.Equal(t, []string{"1", "2", "3"}, items)
+	assert.Equal(
+		t,
+		[]string{"", "second-page", "second-page", "second-page"},
+		requestedCursors,
+	)
+	if !assert.Len(t, errs, 2) {
+		return
+	}
+	var pageErr *PageError
+	assert.ErrorAs(t, errs[0], &pageErr, "temporary errors should be yielded")
+	assert.ErrorIs(t, errs[1], context.Canceled)
+}
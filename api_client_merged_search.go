@@ -0,0 +1,209 @@
+//go:build go1.23
+
+package flareio
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"iter"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// PagingQuery is one of the queries of a merged search.
+type PagingQuery struct {
+	// Name identifies the query in the merged results.
+	Name string
+
+	// Path is the path of the endpoint.
+	Path string
+
+	// Params are the query parameters of every page.
+	Params *url.Values
+
+	// Body is posted as JSON like in IterPostJson.
+	// The query is a GET request when it is nil.
+	Body interface{}
+
+	// Options apply to this query only, after the options of the
+	// merged search.
+	Options []IterOption
+}
+
+// MergedSearch describes queries that are run concurrently and whose
+// items are merged in a single iteration.
+type MergedSearch struct {
+	// Queries are the queries to run.
+	Queries []PagingQuery
+
+	// Concurrency is the number of queries run concurrently.
+	// Defaults to 4.
+	Concurrency int
+
+	// RequestInterval is the minimum delay between two page requests
+	// of the queries, which is shared by all queries.
+	RequestInterval time.Duration
+
+	// ItemKey returns the key used to deduplicate the items found by
+	// several queries. Items with an empty key are never deduplicated.
+	// When nil, items are not deduplicated.
+	ItemKey func(item json.RawMessage) string
+}
+
+// MergedItem is an item of a merged search.
+type MergedItem struct {
+	// Query is the name of the query that found the item. When the
+	// item was found by several queries, it is the first one.
+	Query string
+
+	// Item is the element of the items array of the page.
+	Item json.RawMessage
+}
+
+type mergedResult struct {
+	item MergedItem
+	err  error
+}
+
+// IterMerged allows to iterate over the items of several queries on
+// endpoints that support the Flare standard paging pattern.
+//
+// Queries are run concurrently and their items are yielded as they are
+// received, so items of different queries are interleaved. Errors are
+// yielded along with the name of the query they happened in. A query
+// stops after an error unless it is following, and the iteration
+// continues with the other queries if the consumer doesn't stop. The
+// errors cannot be retried or skipped by the consumer.
+//
+// The options apply to every query, except for start cursors and
+// checkpointers which are ignored, since they can only be set for
+// individual queries. Progress may be reported concurrently.
+//
+// The keys of every item are remembered for the whole iteration.
+// Following queries never end, so the concurrency must allow them to
+// run at once.
+func (client *ApiClient) IterMerged(
+	search MergedSearch,
+	optionFns ...IterOption,
+) iter.Seq2[MergedItem, error] {
+	options := newIterOptions(optionFns)
+	options.startCursor = ""
+	options.checkpointer = nil
+
+	return func(yield func(MergedItem, error) bool) {
+		if len(search.Queries) == 0 {
+			yield(MergedItem{}, errors.New("merged search has no queries"))
+			return
+		}
+
+		concurrency := search.Concurrency
+		if concurrency <= 0 {
+			concurrency = 4
+		}
+
+		var limiter *rateLimiter
+		if search.RequestInterval > 0 {
+			limiter = newRateLimiter(search.RequestInterval)
+		}
+
+		ctx, cancel := context.WithCancel(options.ctx)
+		stopped := make(chan struct{})
+		results := make(chan mergedResult)
+		var wg sync.WaitGroup
+		defer func() {
+			close(stopped)
+			cancel()
+			wg.Wait()
+		}()
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			var queriesWg sync.WaitGroup
+			defer func() {
+				// The consumer is done once every query is.
+				queriesWg.Wait()
+				close(results)
+			}()
+
+			slots := make(chan struct{}, concurrency)
+			for _, query := range search.Queries {
+				select {
+				case slots <- struct{}{}:
+				case <-stopped:
+					return
+				}
+
+				queryOptions := *options
+				for _, optionFn := range query.Options {
+					optionFn(&queryOptions)
+				}
+				queryOptions.ctx = ctx
+				fetchPage := client.queryPageFetcher(query, queryOptions.pagingStrategy)
+				if limiter != nil {
+					fetchPage = limitPageFetcher(fetchPage, limiter)
+				}
+
+				queriesWg.Add(1)
+				go func() {
+					defer queriesWg.Done()
+					defer func() { <-slots }()
+					for item, err := range createItemIterator(fetchPage, &queryOptions) {
+						result := mergedResult{
+							item: MergedItem{
+								Query: query.Name,
+								Item:  item,
+							},
+							err: err,
+						}
+						select {
+						case results <- result:
+						case <-stopped:
+							return
+						}
+					}
+				}()
+			}
+		}()
+
+		seenKeys := map[string]struct{}{}
+		for result := range results {
+			if result.err == nil && search.ItemKey != nil {
+				if key := search.ItemKey(result.item.Item); key != "" {
+					if _, seen := seenKeys[key]; seen {
+						continue
+					}
+					seenKeys[key] = struct{}{}
+				}
+			}
+			if !yield(result.item, result.err) {
+				return
+			}
+		}
+	}
+}
+
+func (client *ApiClient) queryPageFetcher(
+	query PagingQuery,
+	strategy PagingStrategy,
+) pageFetcher {
+	if query.Body == nil {
+		return client.getPageFetcher(query.Path, query.Params, strategy)
+	}
+	return client.postJsonPageFetcher(query.Path, query.Params, query.Body, strategy)
+}
+
+// limitPageFetcher wraps fetchPage so that its requests are spaced
+// out by the given rate limiter.
+func limitPageFetcher(fetchPage pageFetcher, limiter *rateLimiter) pageFetcher {
+	return func(ctx context.Context, cursor string) (*http.Response, error) {
+		if err := limiter.wait(ctx); err != nil {
+			return nil, fmt.Errorf("failed to wait for rate limiter: %w", err)
+		}
+		return fetchPage(ctx, cursor)
+	}
+}
@@ -0,0 +1,228 @@
+//go:build go1.23
+
+package flareio
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newMergedSearchTest serves two pages for each searched fqdn, the
+// second of which contains an item shared by all fqdns.
+func newMergedSearchTest(t *testing.T) *clientTest {
+	return newClientTest(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			type SearchBody struct {
+				Fqdn string `json:"fqdn"`
+				From string `json:"from"`
+			}
+			var body SearchBody
+			if r.Method == http.MethodPost {
+				if err := json.NewDecoder(r.Body).Decode(&body); !assert.NoError(t, err, "Error decoding posted JSON") {
+					return
+				}
+			} else {
+				body.Fqdn = r.URL.Query().Get("fqdn")
+				body.From = r.URL.Query().Get("from")
+			}
+
+			if body.Fqdn == "broken.com" {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			if body.From == "" {
+				fmt.Fprintf(w, `{"next": "second-page", "items": [{"uid": "%s-a"}]}`, body.Fqdn)
+			} else {
+				w.Write([]byte(`{"next": null, "items": [{"uid": "shared"}]}`))
+			}
+		}),
+	)
+}
+
+func mergedSearchTestItemKey(item json.RawMessage) string {
+	var decoded struct {
+		Uid string `json:"uid"`
+	}
+	if err := json.Unmarshal(item, &decoded); err != nil {
+		return ""
+	}
+	return decoded.Uid
+}
+
+func TestIterMerged(t *testing.T) {
+	ct := newMergedSearchTest(t)
+	defer ct.Close()
+
+	found := []string{}
+	for merged, err := range ct.apiClient.IterMerged(
+		MergedSearch{
+			Queries: []PagingQuery{
+				{
+					Name: "first",
+					Path: "/leaksdb/v2/credentials/_search",
+					Body: map[string]interface{}{"fqdn": "first.com"},
+				},
+				{
+					Name: "second",
+					Path: "/leaksdb/v2/credentials/_search",
+					Params: &url.Values{
+						"fqdn": []string{"second.com"},
+					},
+				},
+			},
+			ItemKey: mergedSearchTestItemKey,
+		},
+	) {
+		if len(found) > 10 {
+			// We are going crazy here...
+			break
+		}
+		if !assert.NoError(t, err, "iter yielded an error") {
+			break
+		}
+		uid := mergedSearchTestItemKey(merged.Item)
+		if uid != "shared" {
+			assert.Equal(t, merged.Query+".com-a", uid, "item tagged with the wrong query")
+		}
+		found = append(found, uid)
+	}
+
+	sort.Strings(found)
+	assert.Equal(t, []string{"first.com-a", "second.com-a", "shared"}, found)
+}
+
+func TestIterMergedQueryError(t *testing.T) {
+	ct := newMergedSearchTest(t)
+	defer ct.Close()
+
+	found := []string{}
+	failedQueries := []string{}
+	for merged, err := range ct.apiClient.IterMerged(
+		MergedSearch{
+			Queries: []PagingQuery{
+				{
+					Name: "broken",
+					Path: "/leaksdb/v2/credentials/_search",
+					Body: map[string]interface{}{"fqdn": "broken.com"},
+				},
+				{
+					Name: "working",
+					Path: "/leaksdb/v2/credentials/_search",
+					Body: map[string]interface{}{"fqdn": "working.com"},
+				},
+			},
+		},
+	) {
+		if len(found) > 10 {
+			// We are going crazy here...
+			break
+		}
+		if err != nil {
+			failedQueries = append(failedQueries, merged.Query)
+			continue
+		}
+		found = append(found, mergedSearchTestItemKey(merged.Item))
+	}
+
+	assert.Equal(t, []string{"broken"}, failedQueries)
+	assert.Equal(t, []string{"working.com-a", "shared"}, found)
+}
+
+func TestIterMergedConcurrency(t *testing.T) {
+	var mu sync.Mutex
+	running := 0
+	maxRunning := 0
+	requestTimes := []time.Time{}
+	ct := newClientTest(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			mu.Lock()
+			running = running + 1
+			if running > maxRunning {
+				maxRunning = running
+			}
+			requestTimes = append(requestTimes, time.Now())
+			mu.Unlock()
+
+			time.Sleep(time.Millisecond * 10)
+
+			mu.Lock()
+			running = running - 1
+			mu.Unlock()
+			w.Write([]byte(`{"next": null, "items": [1]}`))
+		}),
+	)
+	defer ct.Close()
+
+	queries := []PagingQuery{}
+	for i := 0; i < 6; i++ {
+		queries = append(queries, PagingQuery{
+			Name: fmt.Sprintf("query-%d", i),
+			Path: "/leaksdb/sources",
+		})
+	}
+
+	itemsFound := 0
+	for _, err := range ct.apiClient.IterMerged(
+		MergedSearch{
+			Queries:         queries,
+			Concurrency:     2,
+			RequestInterval: time.Millisecond * 5,
+		},
+	) {
+		if !assert.NoError(t, err, "iter yielded an error") {
+			break
+		}
+		itemsFound = itemsFound + 1
+	}
+
+	assert.Equal(t, 6, itemsFound)
+	assert.Equal(t, 2, maxRunning)
+	sort.Slice(requestTimes, func(i, j int) bool {
+		return requestTimes[i].Before(requestTimes[j])
+	})
+	assert.GreaterOrEqual(
+		t,
+		requestTimes[len(requestTimes)-1].Sub(requestTimes[0]),
+		time.Millisecond*25,
+		"requests should be spaced out by the shared rate limiter",
+	)
+}
+
+func TestIterMergedEarlyBreak(t *testing.T) {
+	ct := newEndlessPagingTest(t)
+	defer ct.Close()
+
+	itemsFound := 0
+	for _, err := range ct.apiClient.IterMerged(
+		MergedSearch{
+			Queries: []PagingQuery{
+				{Name: "first", Path: "/leaksdb/sources"},
+				{Name: "second", Path: "/leaksdb/sources"},
+			},
+		},
+	) {
+		assert.NoError(t, err, "iter yielded an error")
+		itemsFound = itemsFound + 1
+		if itemsFound == 3 {
+			break
+		}
+	}
+	assert.Equal(t, 3, itemsFound)
+}
+
+func TestIterMergedNoQueries(t *testing.T) {
+	ct := newEndlessPagingTest(t)
+	defer ct.Close()
+
+	for _, err := range ct.apiClient.IterMerged(MergedSearch{}) {
+		assert.ErrorContains(t, err, "merged search has no queries")
+	}
+}
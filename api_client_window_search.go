@@ -0,0 +1,203 @@
+//go:build go1.23
+
+package flareio
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"iter"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// windowItemsBufferSize is the number of items that each window
+// searched in parallel can decode ahead of the consumer.
+const windowItemsBufferSize = 1000
+
+// TimeWindow is the time range covered by one of the searches of a
+// windowed search. It includes its start and excludes its end.
+type TimeWindow struct {
+	Start time.Time
+	End   time.Time
+}
+
+// WindowedSearch describes a search over a date range that is split in
+// smaller time windows.
+type WindowedSearch struct {
+	// Start is the start of the date range.
+	Start time.Time
+
+	// End is the end of the date range.
+	End time.Time
+
+	// WindowSize is the duration covered by each window.
+	// Defaults to 24 hours.
+	WindowSize time.Duration
+
+	// Body returns the body of the search for the given window.
+	Body func(window TimeWindow) interface{}
+
+	// Parallelism is the number of windows searched concurrently.
+	// Defaults to 1.
+	Parallelism int
+
+	// ItemKey returns the key used to deduplicate the items that are
+	// found in two consecutive windows. Items with an empty key are
+	// never deduplicated. When nil, items are not deduplicated.
+	ItemKey func(item json.RawMessage) string
+}
+
+func (search WindowedSearch) windows() ([]TimeWindow, error) {
+	if search.Body == nil {
+		return nil, errors.New("windowed search has no body")
+	}
+	if !search.End.After(search.Start) {
+		return nil, errors.New("windowed search must end after its start")
+	}
+
+	windowSize := search.WindowSize
+	if windowSize <= 0 {
+		windowSize = time.Hour * 24
+	}
+
+	windows := []TimeWindow{}
+	for start := search.Start; start.Before(search.End); start = start.Add(windowSize) {
+		end := start.Add(windowSize)
+		if end.After(search.End) {
+			end = search.End
+		}
+		windows = append(windows, TimeWindow{
+			Start: start,
+			End:   end,
+		})
+	}
+	return windows, nil
+}
+
+type windowItem struct {
+	item json.RawMessage
+	err  error
+}
+
+// IterPostJsonWindowed allows to iterate over the items of a search
+// endpoint that supports the Flare standard paging pattern, running one
+// search per time window of the date range.
+//
+// Windows are searched concurrently according to the parallelism of the
+// search, but items are yielded in chronological order of the windows.
+// An error ends the window it happened in, and the iteration continues
+// with the following window if the consumer doesn't stop.
+//
+// The options apply to the search of each window, except for start
+// cursors, checkpointers and following which are ignored. Progress is
+// reported for each window and may be reported concurrently.
+func (client *ApiClient) IterPostJsonWindowed(
+	path string,
+	params *url.Values,
+	search WindowedSearch,
+	optionFns ...IterOption,
+) iter.Seq2[json.RawMessage, error] {
+	options := newIterOptions(optionFns)
+	options.startCursor = ""
+	options.checkpointer = nil
+	options.follow = nil
+
+	return func(yield func(json.RawMessage, error) bool) {
+		windows, err := search.windows()
+		if err != nil {
+			yield(nil, err)
+			return
+		}
+
+		parallelism := search.Parallelism
+		if parallelism <= 0 {
+			parallelism = 1
+		}
+
+		ctx, cancel := context.WithCancel(options.ctx)
+		stopped := make(chan struct{})
+		var wg sync.WaitGroup
+		defer func() {
+			close(stopped)
+			cancel()
+			wg.Wait()
+		}()
+
+		// Each window gets its items channel when it starts, and the
+		// channels are queued in the order of the windows so that only
+		// about parallelism windows are buffered at a time.
+		pending := make(chan chan windowItem, parallelism)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer close(pending)
+
+			slots := make(chan struct{}, parallelism)
+			for _, window := range windows {
+				items := make(chan windowItem, windowItemsBufferSize)
+				select {
+				case pending <- items:
+				case <-stopped:
+					return
+				}
+
+				select {
+				case slots <- struct{}{}:
+				case <-stopped:
+					return
+				}
+
+				windowOptions := *options
+				windowOptions.ctx = ctx
+				fetchPage := client.postJsonPageFetcher(
+					path,
+					params,
+					search.Body(window),
+					options.pagingStrategy,
+				)
+
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					defer func() { <-slots }()
+					defer close(items)
+					for item, err := range createItemIterator(fetchPage, &windowOptions) {
+						select {
+						case items <- windowItem{item: item, err: err}:
+						case <-stopped:
+							return
+						}
+						if err != nil {
+							return
+						}
+					}
+				}()
+			}
+		}()
+
+		// Windows only overlap with the previous one, so only their
+		// keys need to be remembered.
+		previousKeys := map[string]struct{}{}
+		for items := range pending {
+			currentKeys := map[string]struct{}{}
+			for windowItem := range items {
+				if windowItem.err == nil && search.ItemKey != nil {
+					if key := search.ItemKey(windowItem.item); key != "" {
+						_, inPrevious := previousKeys[key]
+						_, inCurrent := currentKeys[key]
+						currentKeys[key] = struct{}{}
+						if inPrevious || inCurrent {
+							continue
+						}
+					}
+				}
+				if !yield(windowItem.item, windowItem.err) {
+					return
+				}
+			}
+			previousKeys = currentKeys
+		}
+	}
+}
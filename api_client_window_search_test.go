@@ -0,0 +1,218 @@
+//go:build go1.23
+
+package flareio
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type windowSearchTestItem struct {
+	Uid string `json:"uid"`
+}
+
+func newWindowSearchTest(t *testing.T) *clientTest {
+	return newClientTest(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			type SearchBody struct {
+				Gte  time.Time `json:"gte"`
+				Lte  time.Time `json:"lte"`
+				From string    `json:"from"`
+			}
+			var body SearchBody
+			if err := json.NewDecoder(r.Body).Decode(&body); !assert.NoError(t, err, "Error decoding posted JSON") {
+				return
+			}
+
+			// Each window has two pages, and the boundary item is
+			// returned by both windows since lte is inclusive.
+			day := body.Gte.Format("01-02")
+			if body.From == "" {
+				fmt.Fprintf(w, `{"next": "second-page", "items": [{"uid": "%s-a"}]}`, day)
+			} else {
+				fmt.Fprintf(
+					w,
+					`{"next": null, "items": [{"uid": "%s-b"}, {"uid": "boundary-%s"}]}`,
+					day,
+					body.Lte.Format("01-02"),
+				)
+			}
+		}),
+	)
+}
+
+func windowSearchTestBody(window TimeWindow) interface{} {
+	return map[string]interface{}{
+		"gte": window.Start,
+		"lte": window.End,
+	}
+}
+
+func windowSearchTestItemKey(item json.RawMessage) string {
+	var decoded windowSearchTestItem
+	if err := json.Unmarshal(item, &decoded); err != nil {
+		return ""
+	}
+	return decoded.Uid
+}
+
+func TestIterPostJsonWindowed(t *testing.T) {
+	ct := newWindowSearchTest(t)
+	defer ct.Close()
+
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	uids := []string{}
+	for item, err := range ct.apiClient.IterPostJsonWindowed(
+		"/events/_search",
+		nil,
+		WindowedSearch{
+			Start:       start,
+			End:         start.Add(time.Hour * 60),
+			Body:        windowSearchTestBody,
+			Parallelism: 3,
+			ItemKey:     windowSearchTestItemKey,
+		},
+	) {
+		if len(uids) > 20 {
+			// We are going crazy here...
+			break
+		}
+		if !assert.NoError(t, err, "iter yielded an error") {
+			break
+		}
+		uids = append(uids, windowSearchTestItemKey(item))
+	}
+
+	assert.Equal(
+		t,
+		[]string{
+			"01-01-a", "01-01-b", "boundary-01-02",
+			"01-02-a", "01-02-b", "boundary-01-03",
+			"01-03-a", "01-03-b",
+		},
+		uids,
+		"items should be in chronological order and deduplicated",
+	)
+}
+
+func TestIterPostJsonWindowedDeduplicatesOverlaps(t *testing.T) {
+	ct := newClientTest(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(`{"next": null, "items": [{"uid": "same"}, {"uid": "same"}, {}]}`))
+		}),
+	)
+	defer ct.Close()
+
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	items := []string{}
+	for item, err := range ct.apiClient.IterPostJsonWindowed(
+		"/events/_search",
+		nil,
+		WindowedSearch{
+			Start:      start,
+			End:        start.Add(time.Hour * 3),
+			WindowSize: time.Hour,
+			Body:       windowSearchTestBody,
+			ItemKey:    windowSearchTestItemKey,
+		},
+	) {
+		if !assert.NoError(t, err, "iter yielded an error") {
+			break
+		}
+		items = append(items, string(item))
+	}
+
+	assert.Equal(
+		t,
+		[]string{`{"uid": "same"}`, `{}`, `{}`, `{}`},
+		items,
+		"items without a key should never be deduplicated",
+	)
+}
+
+func TestIterPostJsonWindowedEarlyBreak(t *testing.T) {
+	ct := newWindowSearchTest(t)
+	defer ct.Close()
+
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	itemsReceived := 0
+	for _, err := range ct.apiClient.IterPostJsonWindowed(
+		"/events/_search",
+		nil,
+		WindowedSearch{
+			Start:       start,
+			End:         start.Add(time.Hour * 24 * 30),
+			Body:        windowSearchTestBody,
+			Parallelism: 4,
+		},
+	) {
+		assert.NoError(t, err, "iter yielded an error")
+		itemsReceived = itemsReceived + 1
+		if itemsReceived == 4 {
+			break
+		}
+	}
+
+	assert.Equal(t, 4, itemsReceived)
+}
+
+func TestIterPostJsonWindowedBuffers(t *testing.T) {
+	ct := newWindowSearchTest(t)
+	defer ct.Close()
+
+	var before, after runtime.MemStats
+	runtime.ReadMemStats(&before)
+
+	// A year of hourly windows: only the windows that are running or
+	// queued should get an items buffer.
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	for _, err := range ct.apiClient.IterPostJsonWindowed(
+		"/events/_search",
+		nil,
+		WindowedSearch{
+			Start:       start,
+			End:         start.Add(time.Hour * 24 * 365),
+			WindowSize:  time.Hour,
+			Body:        windowSearchTestBody,
+			Parallelism: 4,
+		},
+	) {
+		assert.NoError(t, err, "iter yielded an error")
+		break
+	}
+
+	runtime.ReadMemStats(&after)
+	assert.Less(
+		t,
+		after.TotalAlloc-before.TotalAlloc,
+		uint64(32<<20),
+		"windows should not all be buffered up front",
+	)
+}
+
+func TestIterPostJsonWindowedInvalidRange(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	client := NewApiClient("test-api-key")
+
+	errorsYielded := 0
+	for _, err := range client.IterPostJsonWindowed(
+		"/events/_search",
+		nil,
+		WindowedSearch{
+			Start: start,
+			End:   start,
+			Body:  windowSearchTestBody,
+		},
+	) {
+		errorsYielded = errorsYielded + 1
+		assert.ErrorContains(t, err, "must end after its start")
+	}
+
+	assert.Equal(t, 1, errorsYielded)
+}
@@ -0,0 +1,110 @@
+package flareio
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// maxApiErrorBodySize is the size of the body of an error response
+// that is read to find its message.
+const maxApiErrorBodySize = 4096
+
+// ApiError is returned when the API responds with an unsuccessful
+// status code.
+type ApiError struct {
+	StatusCode int
+
+	// Message is the error message sent by the API, if any.
+	Message string
+}
+
+func (e *ApiError) Error() string {
+	if e.Message == "" {
+		return fmt.Sprintf("got http status code %d", e.StatusCode)
+	}
+	return fmt.Sprintf("got http status code %d: %s", e.StatusCode, e.Message)
+}
+
+// CheckResponse returns an *ApiError if the status code of the
+// response is not 2xx.
+//
+// The body of unsuccessful responses is read to find the error
+// message, but it is never closed.
+func CheckResponse(resp *http.Response) error {
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return nil
+	}
+	apiErr := &ApiError{
+		StatusCode: resp.StatusCode,
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxApiErrorBodySize))
+	if err == nil {
+		apiErr.Message = errorMessage(body)
+	}
+	return apiErr
+}
+
+// DoJson performs an authenticated request at the given path with the
+// given body encoded as JSON, and decodes the response into result if
+// it is not nil.
+//
+// An *ApiError is returned if the API responds with an unsuccessful
+// status code.
+func (client *ApiClient) DoJson(
+	ctx context.Context,
+	method string,
+	path string,
+	body interface{},
+	result interface{},
+) error {
+	var bodyReader io.Reader
+	if body != nil {
+		encoded, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("failed to marshal body to JSON: %w", err)
+		}
+		bodyReader = bytes.NewReader(encoded)
+	}
+
+	request, err := client.newRequest(ctx, method, path, nil, bodyReader)
+	if err != nil {
+		return fmt.Errorf("failed to create http request: %w", err)
+	}
+	if body != nil {
+		request.Header.Set("Content-Type", "application/json")
+	}
+	resp, err := client.do(request, true)
+	if err != nil {
+		return fmt.Errorf("failed to perform request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if err := CheckResponse(resp); err != nil {
+		return err
+	}
+	if result != nil {
+		if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
+			return fmt.Errorf("failed to decode response: %w", err)
+		}
+	}
+	return nil
+}
+
+// errorMessage returns the message of an error response, which is
+// usually a JSON object with a message field.
+func errorMessage(body []byte) string {
+	var decoded map[string]interface{}
+	if err := json.Unmarshal(body, &decoded); err == nil {
+		for _, key := range []string{"message", "error", "detail"} {
+			if message, ok := decoded[key].(string); ok {
+				return message
+			}
+		}
+	}
+	return strings.TrimSpace(string(body))
+}
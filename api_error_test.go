@@ -0,0 +1,94 @@
+package flareio
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckResponse(t *testing.T) {
+	for _, tc := range []struct {
+		statusCode int
+		body       string
+		expected   string
+	}{
+		{http.StatusNotFound, `{"message": "identifier not found"}`, "got http status code 404: identifier not found"},
+		{http.StatusBadRequest, `{"error": "invalid fqdn"}`, "got http status code 400: invalid fqdn"},
+		{http.StatusForbidden, "forbidden\n", "got http status code 403: forbidden"},
+		{http.StatusInternalServerError, "", "got http status code 500"},
+	} {
+		err := CheckResponse(&http.Response{
+			StatusCode: tc.statusCode,
+			Body:       io.NopCloser(strings.NewReader(tc.body)),
+		})
+		assert.EqualError(t, err, tc.expected)
+
+		var apiErr *ApiError
+		if assert.ErrorAs(t, err, &apiErr) {
+			assert.Equal(t, tc.statusCode, apiErr.StatusCode)
+		}
+	}
+
+	assert.NoError(t, CheckResponse(&http.Response{
+		StatusCode: http.StatusNoContent,
+		Body:       io.NopCloser(strings.NewReader("")),
+	}))
+}
+
+func TestDoJson(t *testing.T) {
+	ct := newClientTest(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch r.Method {
+			case http.MethodPut:
+				assert.Equal(t, "/firework/v2/alerts/feeds/12", r.URL.Path)
+				assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+				var body map[string]interface{}
+				if assert.NoError(t, json.NewDecoder(r.Body).Decode(&body)) {
+					assert.Equal(t, map[string]interface{}{"name": "Updated"}, body)
+				}
+				w.Write([]byte(`{"id": 12, "name": "Updated"}`))
+			case http.MethodDelete:
+				assert.Empty(t, r.Header.Get("Content-Type"))
+				w.WriteHeader(http.StatusNoContent)
+			default:
+				w.WriteHeader(http.StatusNotFound)
+				w.Write([]byte(`{"message": "feed not found"}`))
+			}
+		}),
+	)
+	defer ct.Close()
+
+	ctx := context.Background()
+	var updated struct {
+		Id   int    `json:"id"`
+		Name string `json:"name"`
+	}
+	err := ct.apiClient.DoJson(
+		ctx,
+		http.MethodPut,
+		"/firework/v2/alerts/feeds/12",
+		map[string]string{"name": "Updated"},
+		&updated,
+	)
+	if assert.NoError(t, err) {
+		assert.Equal(t, 12, updated.Id)
+		assert.Equal(t, "Updated", updated.Name)
+	}
+
+	assert.NoError(t, ct.apiClient.DoJson(ctx, http.MethodDelete, "/firework/v2/alerts/feeds/12", nil, nil))
+
+	err = ct.apiClient.DoJson(ctx, http.MethodGet, "/firework/v2/alerts/feeds/34", nil, &updated)
+	var apiErr *ApiError
+	if assert.ErrorAs(t, err, &apiErr) {
+		assert.Equal(t, http.StatusNotFound, apiErr.StatusCode)
+		assert.Equal(t, "feed not found", apiErr.Message)
+	}
+
+	err = ct.apiClient.DoJson(ctx, http.MethodPost, "/firework/v2/alerts/feeds", make(chan int), nil)
+	assert.ErrorContains(t, err, "failed to marshal body to JSON")
+}
@@ -0,0 +1,70 @@
+package flareio
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+
+	"github.com/Flared/go-flareio/internal/atomicfile"
+)
+
+// Checkpointer persists the progress of a paging iteration so that
+// it can be resumed after a restart.
+type Checkpointer interface {
+	// LoadCursor returns the saved cursor, or an empty string if
+	// no cursor was saved yet.
+	LoadCursor() (string, error)
+
+	// SaveCursor persists the cursor of the next page to fetch.
+	SaveCursor(cursor string) error
+}
+
+// checkpointCompleted is saved once the consumer is done with the last
+// page, so that a restarted iteration doesn't fetch it again.
+const checkpointCompleted = "flareio:completed"
+
+// FileCheckpointer is a Checkpointer that stores the cursor in a file.
+type FileCheckpointer struct {
+	path string
+}
+
+// NewFileCheckpointer can be used to create a new FileCheckpointer
+// that stores the cursor at the given path.
+func NewFileCheckpointer(path string) *FileCheckpointer {
+	return &FileCheckpointer{
+		path: path,
+	}
+}
+
+// LoadCursor returns the cursor stored in the checkpoint file, or an
+// empty string if the file does not exist.
+func (c *FileCheckpointer) LoadCursor() (string, error) {
+	data, err := os.ReadFile(c.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to read checkpoint file: %w", err)
+	}
+	return string(data), nil
+}
+
+// SaveCursor atomically replaces the content of the checkpoint file
+// with the given cursor.
+func (c *FileCheckpointer) SaveCursor(cursor string) error {
+	if err := atomicfile.WriteFile(c.path, []byte(cursor)); err != nil {
+		return fmt.Errorf("failed to write checkpoint file: %w", err)
+	}
+	return nil
+}
+
+// Clear removes the checkpoint file, so that the next iteration starts
+// over from its start cursor.
+func (c *FileCheckpointer) Clear() error {
+	err := os.Remove(c.path)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("failed to remove checkpoint file: %w", err)
+	}
+	return nil
+}
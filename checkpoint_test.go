@@ -0,0 +1,67 @@
+package flareio
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFileCheckpointerMissingFile(t *testing.T) {
+	checkpointer := NewFileCheckpointer(
+		filepath.Join(t.TempDir(), "checkpoint"),
+	)
+
+	cursor, err := checkpointer.LoadCursor()
+	if !assert.NoError(t, err, "a missing checkpoint should not be an error") {
+		return
+	}
+	assert.Equal(t, "", cursor)
+}
+
+func TestFileCheckpointerSaveAndLoad(t *testing.T) {
+	dir := t.TempDir()
+	checkpointer := NewFileCheckpointer(
+		filepath.Join(dir, "checkpoint"),
+	)
+
+	if !assert.NoError(t, checkpointer.SaveCursor("first-cursor")) {
+		return
+	}
+	if !assert.NoError(t, checkpointer.SaveCursor("second-cursor")) {
+		return
+	}
+
+	cursor, err := checkpointer.LoadCursor()
+	if !assert.NoError(t, err, "failed to load cursor") {
+		return
+	}
+	assert.Equal(t, "second-cursor", cursor)
+
+	entries, err := os.ReadDir(dir)
+	if !assert.NoError(t, err, "failed to list checkpoint dir") {
+		return
+	}
+	assert.Len(t, entries, 1, "temporary files should be cleaned up")
+}
+
+func TestFileCheckpointerClear(t *testing.T) {
+	checkpointer := NewFileCheckpointer(
+		filepath.Join(t.TempDir(), "checkpoint"),
+	)
+
+	if !assert.NoError(t, checkpointer.SaveCursor("cursor")) {
+		return
+	}
+	if !assert.NoError(t, checkpointer.Clear()) {
+		return
+	}
+	cursor, err := checkpointer.LoadCursor()
+	if !assert.NoError(t, err, "failed to load cursor") {
+		return
+	}
+	assert.Equal(t, "", cursor, "a cleared checkpoint should have no cursor")
+
+	assert.NoError(t, checkpointer.Clear(), "clearing a missing checkpoint should not be an error")
+}
@@ -0,0 +1,264 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/Flared/go-flareio"
+)
+
+// Relevance is the feedback of an analyst on whether an event matters
+// to the tenant.
+type Relevance string
+
+const (
+	RelevanceRelevant    Relevance = "relevant"
+	RelevanceNotRelevant Relevance = "not_relevant"
+)
+
+// Action is a triage action that can be applied to events, such as
+// marking them as remediated.
+type Action struct {
+	name string
+	path string
+	body interface{}
+
+	// err is returned when applying an action built from invalid
+	// arguments.
+	err error
+}
+
+// String returns the name of the action.
+func (action Action) String() string {
+	return action.name
+}
+
+// IgnoreAction hides events from the feeds of the tenant.
+func IgnoreAction() Action {
+	return Action{
+		name: "ignore",
+		path: "_ignore",
+	}
+}
+
+// UnignoreAction shows ignored events again.
+func UnignoreAction() Action {
+	return Action{
+		name: "unignore",
+		path: "_unignore",
+	}
+}
+
+// RemediatedAction marks events as remediated.
+func RemediatedAction() Action {
+	return Action{
+		name: "remediate",
+		path: "_remediate",
+	}
+}
+
+// TagAction adds the given tags to events.
+func TagAction(tags ...string) Action {
+	action := Action{
+		name: "tag",
+		path: "_tag",
+		body: map[string]interface{}{
+			"tags": tags,
+		},
+	}
+	if len(tags) == 0 {
+		action.err = errors.New("no tags to add")
+	}
+	for _, tag := range tags {
+		if strings.TrimSpace(tag) == "" {
+			action.err = errors.New("tag is empty")
+		}
+	}
+	return action
+}
+
+// FeedbackAction sends relevance feedback on events, with an optional
+// comment.
+func FeedbackAction(relevance Relevance, comment string) Action {
+	action := Action{
+		name: "feedback",
+		path: "_feedback",
+		body: map[string]interface{}{
+			"relevance": relevance,
+			"comment":   comment,
+		},
+	}
+	if relevance != RelevanceRelevant && relevance != RelevanceNotRelevant {
+		action.err = fmt.Errorf("unknown relevance %q", relevance)
+	}
+	return action
+}
+
+func (action Action) validate() error {
+	if action.path == "" {
+		return errors.New("unknown event action")
+	}
+	return action.err
+}
+
+// ApplyAction applies the action to the event with the given uid.
+//
+// ErrEventNotFound is returned if it does not exist.
+func (client *Client) ApplyAction(ctx context.Context, uid string, action Action) error {
+	if uid == "" {
+		return errors.New("event uid is empty")
+	}
+	if err := action.validate(); err != nil {
+		return err
+	}
+
+	err := client.apiClient.DoJson(
+		ctx,
+		http.MethodPost,
+		eventPath(uid)+"/"+action.path,
+		action.body,
+		nil,
+	)
+	var apiErr *flareio.ApiError
+	if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
+		return fmt.Errorf("%w: %q", ErrEventNotFound, uid)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to apply %s action: %w", action, err)
+	}
+	return nil
+}
+
+// IgnoreEvent hides the event from the feeds of the tenant.
+func (client *Client) IgnoreEvent(ctx context.Context, uid string) error {
+	return client.ApplyAction(ctx, uid, IgnoreAction())
+}
+
+// UnignoreEvent shows the ignored event again.
+func (client *Client) UnignoreEvent(ctx context.Context, uid string) error {
+	return client.ApplyAction(ctx, uid, UnignoreAction())
+}
+
+// MarkEventRemediated marks the event as remediated.
+func (client *Client) MarkEventRemediated(ctx context.Context, uid string) error {
+	return client.ApplyAction(ctx, uid, RemediatedAction())
+}
+
+// TagEvent adds the given tags to the event.
+func (client *Client) TagEvent(ctx context.Context, uid string, tags ...string) error {
+	return client.ApplyAction(ctx, uid, TagAction(tags...))
+}
+
+// SendEventFeedback sends relevance feedback on the event.
+func (client *Client) SendEventFeedback(
+	ctx context.Context,
+	uid string,
+	relevance Relevance,
+	comment string,
+) error {
+	return client.ApplyAction(ctx, uid, FeedbackAction(relevance, comment))
+}
+
+// ActionResult is the outcome of an action applied to an event.
+type ActionResult struct {
+	Uid string
+
+	// Err is nil if the action was applied.
+	Err error
+}
+
+// BulkResults are the outcomes of an action applied to many events,
+// in the order of their uids.
+type BulkResults []ActionResult
+
+// Failed returns the results of the events that the action could not
+// be applied to.
+func (results BulkResults) Failed() []ActionResult {
+	failed := []ActionResult{}
+	for _, result := range results {
+		if result.Err != nil {
+			failed = append(failed, result)
+		}
+	}
+	return failed
+}
+
+// Err returns an error that wraps the first failure, if any.
+func (results BulkResults) Err() error {
+	failed := results.Failed()
+	if len(failed) == 0 {
+		return nil
+	}
+	return fmt.Errorf(
+		"failed to apply action to %d of %d events, first error on %q: %w",
+		len(failed),
+		len(results),
+		failed[0].Uid,
+		failed[0].Err,
+	)
+}
+
+// ApplyActionBulk applies the action to the events with the given uids.
+//
+// The action is applied to up to parallelism events concurrently,
+// using the rate limiter of the client. A failure doesn't stop the
+// other events, and the outcome of every event is returned. Events
+// that were not reached before ctx is done fail with its error.
+func (client *Client) ApplyActionBulk(
+	ctx context.Context,
+	uids []string,
+	action Action,
+	parallelism int,
+) BulkResults {
+	if parallelism <= 0 {
+		parallelism = 1
+	}
+
+	results := make(BulkResults, len(uids))
+	slots := make(chan struct{}, parallelism)
+	var wg sync.WaitGroup
+	for i, uid := range uids {
+		results[i].Uid = uid
+		select {
+		case slots <- struct{}{}:
+		case <-ctx.Done():
+			results[i].Err = ctx.Err()
+			continue
+		}
+		wg.Add(1)
+		go func(i int, uid string) {
+			defer wg.Done()
+			defer func() { <-slots }()
+			results[i].Err = client.ApplyAction(ctx, uid, action)
+		}(i, uid)
+	}
+	wg.Wait()
+	return results
+}
+
+// IgnoreEvents hides the events from the feeds of the tenant. It
+// behaves like ApplyActionBulk.
+func (client *Client) IgnoreEvents(ctx context.Context, uids []string, parallelism int) BulkResults {
+	return client.ApplyActionBulk(ctx, uids, IgnoreAction(), parallelism)
+}
+
+// MarkEventsRemediated marks the events as remediated. It behaves like
+// ApplyActionBulk.
+func (client *Client) MarkEventsRemediated(ctx context.Context, uids []string, parallelism int) BulkResults {
+	return client.ApplyActionBulk(ctx, uids, RemediatedAction(), parallelism)
+}
+
+// TagEvents adds the given tags to the events. It behaves like
+// ApplyActionBulk.
+func (client *Client) TagEvents(
+	ctx context.Context,
+	uids []string,
+	parallelism int,
+	tags ...string,
+) BulkResults {
+	return client.ApplyActionBulk(ctx, uids, TagAction(tags...), parallelism)
+}
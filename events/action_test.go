@@ -0,0 +1,152 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Flared/go-flareio"
+	"github.com/Flared/go-flareio/flareiotest"
+	"github.com/stretchr/testify/assert"
+)
+
+// newActionsTest records the actions applied to events, and fails for
+// the events whose uid ends with "missing" or "broken".
+func newActionsTest(t *testing.T) (*flareiotest.Server, *[]string) {
+	var mu sync.Mutex
+	applied := []string{}
+	server := flareiotest.NewServer(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodPost, r.Method)
+			switch {
+			case strings.HasPrefix(r.URL.Path, "/firework/v4/events/paste/missing/"):
+				w.WriteHeader(http.StatusNotFound)
+				return
+			case strings.HasPrefix(r.URL.Path, "/firework/v4/events/paste/broken/"):
+				w.WriteHeader(http.StatusForbidden)
+				w.Write([]byte(`{"message": "not allowed"}`))
+				return
+			}
+
+			var body map[string]interface{}
+			json.NewDecoder(r.Body).Decode(&body)
+			encoded, _ := json.Marshal(body)
+
+			mu.Lock()
+			defer mu.Unlock()
+			applied = append(applied, r.URL.Path[len("/firework/v4/events/"):]+" "+string(encoded))
+		}),
+	)
+	return server, &applied
+}
+
+func TestApplyAction(t *testing.T) {
+	server, applied := newActionsTest(t)
+	defer server.Close()
+
+	ctx := context.Background()
+	client := NewClient(server.Client())
+
+	assert.NoError(t, client.IgnoreEvent(ctx, "paste/1"))
+	assert.NoError(t, client.UnignoreEvent(ctx, "paste/1"))
+	assert.NoError(t, client.MarkEventRemediated(ctx, "paste/1"))
+	assert.NoError(t, client.TagEvent(ctx, "paste/1", "phishing", "triaged"))
+	assert.NoError(t, client.SendEventFeedback(ctx, "paste/1", RelevanceNotRelevant, "test data"))
+	assert.Equal(
+		t,
+		[]string{
+			"paste/1/_ignore null",
+			"paste/1/_unignore null",
+			"paste/1/_remediate null",
+			`paste/1/_tag {"tags":["phishing","triaged"]}`,
+			`paste/1/_feedback {"comment":"test data","relevance":"not_relevant"}`,
+		},
+		*applied,
+	)
+}
+
+func TestApplyActionErrors(t *testing.T) {
+	server, applied := newActionsTest(t)
+	defer server.Close()
+
+	ctx := context.Background()
+	client := NewClient(server.Client())
+
+	assert.ErrorIs(t, client.IgnoreEvent(ctx, "paste/missing"), ErrEventNotFound)
+
+	err := client.MarkEventRemediated(ctx, "paste/broken")
+	assert.EqualError(t, err, "failed to apply remediate action: got http status code 403: not allowed")
+	var apiErr *flareio.ApiError
+	assert.ErrorAs(t, err, &apiErr)
+
+	assert.EqualError(t, client.IgnoreEvent(ctx, ""), "event uid is empty")
+	assert.EqualError(t, client.TagEvent(ctx, "paste/1"), "no tags to add")
+	assert.EqualError(t, client.TagEvent(ctx, "paste/1", "ok", " "), "tag is empty")
+	assert.EqualError(t, client.SendEventFeedback(ctx, "paste/1", "maybe", ""), `unknown relevance "maybe"`)
+	assert.EqualError(t, client.ApplyAction(ctx, "paste/1", Action{}), "unknown event action")
+	assert.Empty(t, *applied, "invalid actions should not be sent")
+}
+
+func TestApplyActionBulk(t *testing.T) {
+	var inFlight, maxInFlight int32
+	server := flareiotest.NewServer(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			current := atomic.AddInt32(&inFlight, 1)
+			defer atomic.AddInt32(&inFlight, -1)
+			for {
+				max := atomic.LoadInt32(&maxInFlight)
+				if current <= max || atomic.CompareAndSwapInt32(&maxInFlight, max, current) {
+					break
+				}
+			}
+			time.Sleep(10 * time.Millisecond)
+			if strings.HasPrefix(r.URL.Path, "/firework/v4/events/paste/missing/") {
+				w.WriteHeader(http.StatusNotFound)
+			}
+		}),
+	)
+	defer server.Close()
+
+	client := NewClient(server.Client())
+	uids := []string{"paste/1", "paste/missing", "paste/3", "paste/4", "paste/5", "paste/6"}
+	results := client.TagEvents(context.Background(), uids, 2, "triaged")
+
+	if !assert.Len(t, results, len(uids)) {
+		return
+	}
+	for i, result := range results {
+		assert.Equal(t, uids[i], result.Uid, "results should be in the order of the uids")
+	}
+	assert.LessOrEqual(t, atomic.LoadInt32(&maxInFlight), int32(2))
+
+	failed := results.Failed()
+	if assert.Len(t, failed, 1) {
+		assert.Equal(t, "paste/missing", failed[0].Uid)
+		assert.ErrorIs(t, failed[0].Err, ErrEventNotFound)
+	}
+	err := results.Err()
+	assert.ErrorIs(t, err, ErrEventNotFound)
+	assert.ErrorContains(t, err, `failed to apply action to 1 of 6 events, first error on "paste/missing"`)
+}
+
+func TestApplyActionBulkCanceled(t *testing.T) {
+	server, applied := newActionsTest(t)
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	client := NewClient(server.Client())
+	results := client.IgnoreEvents(ctx, []string{"paste/1", "paste/2"}, 1)
+	for _, result := range results {
+		assert.ErrorIs(t, result.Err, context.Canceled)
+	}
+	assert.Empty(t, *applied)
+
+	assert.NoError(t, client.MarkEventsRemediated(context.Background(), nil, 4).Err())
+}
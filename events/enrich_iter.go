@@ -0,0 +1,107 @@
+//go:build go1.23
+
+package events
+
+import (
+	"context"
+	"iter"
+	"sync"
+)
+
+type enrichResult struct {
+	event Event
+	err   error
+}
+
+// IterEnrichedEvents allows to iterate over the details of the events
+// yielded by summaries, such as the events of IterTenantEvents.
+//
+// The details of up to parallelism events are fetched concurrently,
+// using the rate limiter of the client, and the events are yielded in
+// the order of the summaries. An event whose details could not be
+// fetched is yielded as an *EnrichError, and errors of summaries are
+// yielded as is. The iteration continues after errors if the consumer
+// doesn't stop.
+//
+// The summaries are consumed ahead of the consumer, using their own
+// context. Summaries that use a checkpointer or follow mode are not
+// supported: a page could be checkpointed before the consumer is done
+// with its events, and stopping the iteration would wait for the next
+// poll.
+func (client *Client) IterEnrichedEvents(
+	ctx context.Context,
+	summaries iter.Seq2[*Summary, error],
+	parallelism int,
+) iter.Seq2[Event, error] {
+	if parallelism <= 0 {
+		parallelism = 1
+	}
+
+	return func(yield func(Event, error) bool) {
+		ctx, cancel := context.WithCancel(ctx)
+		stopped := make(chan struct{})
+		var wg sync.WaitGroup
+		defer func() {
+			close(stopped)
+			cancel()
+			wg.Wait()
+		}()
+
+		// Each summary gets a result channel, which are queued in the
+		// order of the summaries.
+		pending := make(chan chan enrichResult, parallelism)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer close(pending)
+
+			slots := make(chan struct{}, parallelism)
+			for summary, err := range summaries {
+				result := make(chan enrichResult, 1)
+				select {
+				case pending <- result:
+				case <-stopped:
+					return
+				}
+
+				if err != nil {
+					result <- enrichResult{err: err}
+					continue
+				}
+
+				select {
+				case slots <- struct{}{}:
+				case <-stopped:
+					return
+				}
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					defer func() { <-slots }()
+					uid := summary.Metadata.Uid
+					event, err := client.GetEvent(ctx, uid)
+					if err != nil {
+						err = &EnrichError{
+							Uid: uid,
+							Err: err,
+						}
+					}
+					result <- enrichResult{event: event, err: err}
+				}()
+			}
+		}()
+
+		for result := range pending {
+			var enriched enrichResult
+			select {
+			case enriched = <-result:
+			case <-ctx.Done():
+				yield(nil, ctx.Err())
+				return
+			}
+			if !yield(enriched.event, enriched.err) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,119 @@
+//go:build go1.23
+
+package events
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"iter"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func summariesOf(uids ...string) iter.Seq2[*Summary, error] {
+	return func(yield func(*Summary, error) bool) {
+		for _, uid := range uids {
+			if uid == "" {
+				if !yield(nil, errors.New("search failed")) {
+					return
+				}
+				continue
+			}
+			summary := &Summary{
+				Metadata: Metadata{
+					Uid:  uid,
+					Type: TypePaste,
+				},
+			}
+			if !yield(summary, nil) {
+				return
+			}
+		}
+	}
+}
+
+func TestIterEnrichedEvents(t *testing.T) {
+	server := newEventsTest(t)
+	defer server.Close()
+
+	uids := []string{}
+	for i := 0; i < 20; i++ {
+		uids = append(uids, fmt.Sprintf("paste/%d", i))
+	}
+
+	titles := []string{}
+	for event, err := range NewClient(server.Client()).IterEnrichedEvents(
+		context.Background(),
+		summariesOf(uids...),
+		4,
+	) {
+		if !assert.NoError(t, err, "iter yielded an error") {
+			break
+		}
+		titles = append(titles, event.(*Paste).Title)
+	}
+
+	expected := []string{}
+	for _, uid := range uids {
+		expected = append(expected, "title of "+uid)
+	}
+	assert.Equal(t, expected, titles, "events should be yielded in order")
+}
+
+func TestIterEnrichedEventsErrors(t *testing.T) {
+	server := newEventsTest(t)
+	defer server.Close()
+
+	results := []string{}
+	for event, err := range NewClient(server.Client()).IterEnrichedEvents(
+		context.Background(),
+		summariesOf("paste/1", "paste/broken", "", "paste/2"),
+		2,
+	) {
+		var enrichErr *EnrichError
+		if errors.As(err, &enrichErr) {
+			results = append(results, "enrich error: "+enrichErr.Uid)
+		} else if err != nil {
+			results = append(results, "error: "+err.Error())
+		} else {
+			results = append(results, event.EventMetadata().Uid)
+		}
+	}
+
+	assert.Equal(
+		t,
+		[]string{
+			"paste/1",
+			"enrich error: paste/broken",
+			"error: search failed",
+			"paste/2",
+		},
+		results,
+	)
+}
+
+func TestIterEnrichedEventsEarlyBreak(t *testing.T) {
+	server := newEventsTest(t)
+	defer server.Close()
+
+	uids := []string{}
+	for i := 0; i < 100; i++ {
+		uids = append(uids, fmt.Sprintf("paste/%d", i))
+	}
+
+	eventsFound := 0
+	for _, err := range NewClient(server.Client()).IterEnrichedEvents(
+		context.Background(),
+		summariesOf(uids...),
+		4,
+	) {
+		assert.NoError(t, err, "iter yielded an error")
+		eventsFound = eventsFound + 1
+		if eventsFound == 3 {
+			break
+		}
+	}
+	assert.Equal(t, 3, eventsFound)
+}
@@ -0,0 +1,63 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/Flared/go-flareio"
+)
+
+// ErrEventNotFound is returned when an event does not exist.
+var ErrEventNotFound = errors.New("event not found")
+
+// eventPath returns the path of the event with the given uid, whose
+// segments are escaped separately since uids contain slashes.
+func eventPath(uid string) string {
+	segments := strings.Split(uid, "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+	return "/firework/v4/events/" + strings.Join(segments, "/")
+}
+
+// GetEvent fetches the details of the event with the given uid.
+//
+// ErrEventNotFound is returned if it does not exist.
+func (client *Client) GetEvent(ctx context.Context, uid string) (Event, error) {
+	if uid == "" {
+		return nil, errors.New("event uid is empty")
+	}
+
+	var body json.RawMessage
+	err := client.apiClient.DoJson(ctx, http.MethodGet, eventPath(uid), nil, &body)
+	var apiErr *flareio.ApiError
+	if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("%w: %q", ErrEventNotFound, uid)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch event: %w", err)
+	}
+	return DecodeEvent(body)
+}
+
+// EnrichError is returned when the details of an event that was found
+// by a search could not be fetched.
+type EnrichError struct {
+	// Uid is the uid of the event.
+	Uid string
+
+	Err error
+}
+
+func (e *EnrichError) Error() string {
+	return fmt.Sprintf("failed to enrich event %q: %s", e.Uid, e.Err)
+}
+
+func (e *EnrichError) Unwrap() error {
+	return e.Err
+}
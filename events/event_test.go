@@ -0,0 +1,57 @@
+package events
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/Flared/go-flareio/flareiotest"
+	"github.com/stretchr/testify/assert"
+)
+
+// newEventsTest serves the details of paste events, and fails for the
+// events whose uid ends with "broken".
+func newEventsTest(t *testing.T) *flareiotest.Server {
+	return flareiotest.NewServer(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch r.URL.Path {
+			case "/firework/v4/events/paste/broken":
+				w.WriteHeader(http.StatusForbidden)
+			case "/firework/v4/events/paste/missing":
+				w.WriteHeader(http.StatusNotFound)
+			default:
+				uid := r.URL.Path[len("/firework/v4/events/"):]
+				w.Write([]byte(`{
+					"metadata": {"uid": "` + uid + `", "type": "paste"},
+					"data": {"title": "title of ` + uid + `"}
+				}`))
+			}
+		}),
+	)
+}
+
+func TestEventPath(t *testing.T) {
+	assert.Equal(t, "/firework/v4/events/forum_post/1", eventPath("forum_post/1"))
+	assert.Equal(t, "/firework/v4/events/paste/a%3Fb", eventPath("paste/a?b"))
+}
+
+func TestGetEvent(t *testing.T) {
+	server := newEventsTest(t)
+	defer server.Close()
+
+	client := NewClient(server.Client())
+	event, err := client.GetEvent(context.Background(), "paste/1")
+	if assert.NoError(t, err) {
+		assert.Equal(t, "title of paste/1", event.(*Paste).Title)
+		assert.Equal(t, "paste/1", event.EventMetadata().Uid)
+	}
+
+	_, err = client.GetEvent(context.Background(), "paste/missing")
+	assert.ErrorIs(t, err, ErrEventNotFound)
+
+	_, err = client.GetEvent(context.Background(), "paste/broken")
+	assert.ErrorContains(t, err, "got http status code 403")
+
+	_, err = client.GetEvent(context.Background(), "")
+	assert.ErrorContains(t, err, "event uid is empty")
+}
@@ -0,0 +1,277 @@
+package events
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Event is an event of any type.
+//
+// It is one of the concrete event types of this package, such as
+// *ForumPost, or a *RawEvent for types that this package doesn't know.
+// Events are encoded to JSON in the envelope that DecodeEvent decodes.
+type Event interface {
+	// EventMetadata returns the metadata shared by all events.
+	EventMetadata() Metadata
+
+	setMetadata(metadata Metadata)
+}
+
+// EventMetadata returns the metadata, which makes every event
+// that embeds it an Event.
+func (metadata Metadata) EventMetadata() Metadata {
+	return metadata
+}
+
+func (metadata *Metadata) setMetadata(decoded Metadata) {
+	*metadata = decoded
+}
+
+// LeakedCredential is a credential found in a leak.
+type LeakedCredential struct {
+	Metadata `json:"-"`
+
+	IdentityName string `json:"identity_name"`
+	Domain       string `json:"domain"`
+	Hash         string `json:"hash"`
+	HashType     string `json:"hash_type"`
+	SourceId     string `json:"source_id"`
+}
+
+// ForumPost is a post on a forum.
+type ForumPost struct {
+	Metadata `json:"-"`
+
+	Forum    string     `json:"forum"`
+	Title    string     `json:"title"`
+	Content  string     `json:"content"`
+	Author   string     `json:"author"`
+	Url      string     `json:"url"`
+	PostedAt *time.Time `json:"posted_at"`
+}
+
+// Listing is an item for sale on a marketplace.
+type Listing struct {
+	Metadata `json:"-"`
+
+	Marketplace string `json:"marketplace"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	Vendor      string `json:"vendor"`
+	Price       string `json:"price"`
+	Url         string `json:"url"`
+}
+
+// ChatMessage is a message sent on a chat platform, such as Telegram.
+type ChatMessage struct {
+	Metadata `json:"-"`
+
+	Platform string     `json:"platform"`
+	Channel  string     `json:"channel"`
+	Content  string     `json:"content"`
+	Author   string     `json:"author"`
+	SentAt   *time.Time `json:"sent_at"`
+}
+
+// Paste is a text shared on a paste site.
+type Paste struct {
+	Metadata `json:"-"`
+
+	Site    string `json:"site"`
+	Title   string `json:"title"`
+	Content string `json:"content"`
+	Author  string `json:"author"`
+	Url     string `json:"url"`
+}
+
+// StealerLogCredential is a credential found in a stealer log.
+type StealerLogCredential struct {
+	Url      string `json:"url"`
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+// StealerLogCookie is a browser cookie found in a stealer log.
+type StealerLogCookie struct {
+	Domain    string     `json:"domain"`
+	Name      string     `json:"name"`
+	Path      string     `json:"path"`
+	ExpiresAt *time.Time `json:"expires_at"`
+}
+
+// StealerLog is the data stolen from an infected device.
+type StealerLog struct {
+	Metadata `json:"-"`
+
+	MalwareFamily string `json:"malware_family"`
+
+	// Hostname, Ip, Country, OperatingSystem, ComputerUsername and Hwid
+	// describe the infected device, when known.
+	Hostname         string `json:"hostname"`
+	Ip               string `json:"ip"`
+	Country          string `json:"country"`
+	OperatingSystem  string `json:"operating_system"`
+	ComputerUsername string `json:"computer_username"`
+	Hwid             string `json:"hwid"`
+
+	InfectedAt *time.Time `json:"infected_at"`
+
+	// Domains are the domains of the tenant that the log exposes.
+	Domains []string `json:"domains"`
+
+	Credentials []StealerLogCredential `json:"credentials"`
+	Cookies     []StealerLogCookie     `json:"cookies"`
+}
+
+// RansomLeak is a post on the leak site of a ransomware group.
+type RansomLeak struct {
+	Metadata `json:"-"`
+
+	Group       string     `json:"group"`
+	Victim      string     `json:"victim"`
+	Content     string     `json:"content"`
+	Url         string     `json:"url"`
+	PublishedAt *time.Time `json:"published_at"`
+}
+
+// Lookalike is a registered domain that looks like a domain of the tenant.
+type Lookalike struct {
+	Metadata `json:"-"`
+
+	Domain       string     `json:"domain"`
+	TargetDomain string     `json:"target_domain"`
+	Registrar    string     `json:"registrar"`
+	RegisteredAt *time.Time `json:"registered_at"`
+}
+
+// BlogPost is a post on a blog.
+type BlogPost struct {
+	Metadata `json:"-"`
+
+	Blog    string `json:"blog"`
+	Title   string `json:"title"`
+	Content string `json:"content"`
+	Author  string `json:"author"`
+	Url     string `json:"url"`
+}
+
+// RawEvent is an event of a type that this package doesn't know.
+type RawEvent struct {
+	Metadata `json:"-"`
+
+	// Data is the undecoded data of the event.
+	Data json.RawMessage `json:"-"`
+}
+
+var eventTypes = map[EventType]func() Event{
+	TypeLeakedCredential: func() Event { return &LeakedCredential{} },
+	TypeForumPost:        func() Event { return &ForumPost{} },
+	TypeListing:          func() Event { return &Listing{} },
+	TypeChatMessage:      func() Event { return &ChatMessage{} },
+	TypePaste:            func() Event { return &Paste{} },
+	TypeStealerLog:       func() Event { return &StealerLog{} },
+	TypeRansomLeak:       func() Event { return &RansomLeak{} },
+	TypeLookalike:        func() Event { return &Lookalike{} },
+	TypeBlogPost:         func() Event { return &BlogPost{} },
+}
+
+// DecodeEvent decodes an event into the concrete type that matches
+// its type, or into a *RawEvent if its type is unknown.
+func DecodeEvent(data []byte) (Event, error) {
+	var envelope struct {
+		Metadata Metadata        `json:"metadata"`
+		Data     json.RawMessage `json:"data"`
+	}
+	if err := json.Unmarshal(data, &envelope); err != nil {
+		return nil, fmt.Errorf("failed to decode event: %w", err)
+	}
+
+	newEvent, known := eventTypes[envelope.Metadata.Type]
+	if !known {
+		event := &RawEvent{
+			Data: envelope.Data,
+		}
+		event.setMetadata(envelope.Metadata)
+		return event, nil
+	}
+
+	event := newEvent()
+	if len(envelope.Data) > 0 {
+		if err := json.Unmarshal(envelope.Data, event); err != nil {
+			return nil, fmt.Errorf(
+				"failed to decode %s event %q: %w",
+				envelope.Metadata.Type,
+				envelope.Metadata.Uid,
+				err,
+			)
+		}
+	}
+	event.setMetadata(envelope.Metadata)
+	return event, nil
+}
+
+// marshalEvent encodes an event in the envelope that DecodeEvent
+// decodes, so that events can be encoded and decoded again without
+// losing their metadata.
+func marshalEvent(metadata Metadata, data interface{}) ([]byte, error) {
+	return json.Marshal(struct {
+		Metadata Metadata    `json:"metadata"`
+		Data     interface{} `json:"data"`
+	}{
+		Metadata: metadata,
+		Data:     data,
+	})
+}
+
+// The fields of events are encoded without their MarshalJSON method by
+// converting them to types that don't have it.
+
+func (credential LeakedCredential) MarshalJSON() ([]byte, error) {
+	type data LeakedCredential
+	return marshalEvent(credential.Metadata, data(credential))
+}
+
+func (post ForumPost) MarshalJSON() ([]byte, error) {
+	type data ForumPost
+	return marshalEvent(post.Metadata, data(post))
+}
+
+func (listing Listing) MarshalJSON() ([]byte, error) {
+	type data Listing
+	return marshalEvent(listing.Metadata, data(listing))
+}
+
+func (message ChatMessage) MarshalJSON() ([]byte, error) {
+	type data ChatMessage
+	return marshalEvent(message.Metadata, data(message))
+}
+
+func (paste Paste) MarshalJSON() ([]byte, error) {
+	type data Paste
+	return marshalEvent(paste.Metadata, data(paste))
+}
+
+func (stealerLog StealerLog) MarshalJSON() ([]byte, error) {
+	type data StealerLog
+	return marshalEvent(stealerLog.Metadata, data(stealerLog))
+}
+
+func (leak RansomLeak) MarshalJSON() ([]byte, error) {
+	type data RansomLeak
+	return marshalEvent(leak.Metadata, data(leak))
+}
+
+func (lookalike Lookalike) MarshalJSON() ([]byte, error) {
+	type data Lookalike
+	return marshalEvent(lookalike.Metadata, data(lookalike))
+}
+
+func (post BlogPost) MarshalJSON() ([]byte, error) {
+	type data BlogPost
+	return marshalEvent(post.Metadata, data(post))
+}
+
+func (event RawEvent) MarshalJSON() ([]byte, error) {
+	return marshalEvent(event.Metadata, event.Data)
+}
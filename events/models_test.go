@@ -0,0 +1,164 @@
+package events
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecodeEvent(t *testing.T) {
+	event, err := DecodeEvent([]byte(`{
+		"metadata": {
+			"uid": "forum_post/1",
+			"type": "forum_post",
+			"severity": "high",
+			"matched_identifiers": [{"id": 12, "name": "acme.com", "type": "domain"}]
+		},
+		"data": {
+			"forum": "exploit",
+			"title": "Selling access",
+			"content": "acme.com VPN access",
+			"posted_at": "2024-01-01T10:00:00Z"
+		}
+	}`))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	forumPost, ok := event.(*ForumPost)
+	if !assert.True(t, ok, "expected a forum post, got %T", event) {
+		return
+	}
+	postedAt := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	assert.Equal(t, &ForumPost{
+		Metadata: Metadata{
+			Uid:      "forum_post/1",
+			Type:     TypeForumPost,
+			Severity: SeverityHigh,
+			MatchedIdentifiers: []MatchedIdentifier{
+				{Id: 12, Name: "acme.com", Type: "domain"},
+			},
+		},
+		Forum:    "exploit",
+		Title:    "Selling access",
+		Content:  "acme.com VPN access",
+		PostedAt: &postedAt,
+	}, forumPost)
+	assert.Equal(t, "forum_post/1", event.EventMetadata().Uid)
+}
+
+func TestDecodeEventTypes(t *testing.T) {
+	for eventType, expected := range map[EventType]Event{
+		TypeLeakedCredential: &LeakedCredential{},
+		TypeForumPost:        &ForumPost{},
+		TypeListing:          &Listing{},
+		TypeChatMessage:      &ChatMessage{},
+		TypePaste:            &Paste{},
+		TypeStealerLog:       &StealerLog{},
+		TypeRansomLeak:       &RansomLeak{},
+		TypeLookalike:        &Lookalike{},
+		TypeBlogPost:         &BlogPost{},
+	} {
+		event, err := DecodeEvent([]byte(`{"metadata": {"type": "` + string(eventType) + `"}}`))
+		if !assert.NoError(t, err) {
+			continue
+		}
+		assert.IsType(t, expected, event)
+		assert.Equal(t, eventType, event.EventMetadata().Type)
+	}
+}
+
+func TestDecodeEventUnknownType(t *testing.T) {
+	event, err := DecodeEvent([]byte(`{
+		"metadata": {"uid": "hologram/1", "type": "hologram"},
+		"data": {"color": "blue"}
+	}`))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	rawEvent, ok := event.(*RawEvent)
+	if !assert.True(t, ok, "expected a raw event, got %T", event) {
+		return
+	}
+	assert.Equal(t, EventType("hologram"), rawEvent.Type)
+	assert.Equal(t, json.RawMessage(`{"color": "blue"}`), rawEvent.Data)
+}
+
+func TestDecodeEventInvalidData(t *testing.T) {
+	_, err := DecodeEvent([]byte(`{
+		"metadata": {"uid": "paste/1", "type": "paste"},
+		"data": {"title": 12}
+	}`))
+	assert.ErrorContains(t, err, `failed to decode paste event "paste/1"`)
+
+	_, err = DecodeEvent([]byte(`[]`))
+	assert.ErrorContains(t, err, "failed to decode event")
+}
+
+func TestMarshalEvent(t *testing.T) {
+	postedAt := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	for _, event := range []Event{
+		&ForumPost{
+			Metadata: Metadata{
+				Uid:      "forum_post/1",
+				Type:     TypeForumPost,
+				Severity: SeverityHigh,
+				MatchedIdentifiers: []MatchedIdentifier{
+					{Id: 12, Name: "acme.com", Type: "domain"},
+				},
+			},
+			Forum:    "exploit",
+			Title:    "Selling access",
+			PostedAt: &postedAt,
+		},
+		&LeakedCredential{
+			Metadata:     Metadata{Uid: "leak/1", Type: TypeLeakedCredential},
+			IdentityName: "john@acme.com",
+		},
+		&StealerLog{
+			Metadata: Metadata{Uid: "stealer_log/1", Type: TypeStealerLog},
+			Hostname: "DESKTOP-1",
+			Credentials: []StealerLogCredential{
+				{Url: "https://sso.acme.com", Username: "john@acme.com"},
+			},
+		},
+		&RawEvent{
+			Metadata: Metadata{Uid: "hologram/1", Type: "hologram", Severity: SeverityLow},
+			Data:     json.RawMessage(`{"color":"blue"}`),
+		},
+	} {
+		encoded, err := json.Marshal(event)
+		if !assert.NoError(t, err) {
+			continue
+		}
+		decoded, err := DecodeEvent(encoded)
+		if assert.NoError(t, err) {
+			assert.Equal(t, event, decoded, "event should survive a round trip: %s", encoded)
+		}
+	}
+
+	encoded, err := json.Marshal(Paste{
+		Metadata: Metadata{Uid: "paste/1", Type: TypePaste},
+		Title:    "dump",
+	})
+	if assert.NoError(t, err) {
+		assert.JSONEq(
+			t,
+			`{
+				"metadata": {
+					"uid": "paste/1",
+					"type": "paste",
+					"severity": "",
+					"estimated_created_at": null,
+					"materialized_at": null,
+					"matched_identifiers": null
+				},
+				"data": {"site": "", "title": "dump", "content": "", "author": "", "url": ""}
+			}`,
+			string(encoded),
+		)
+	}
+}
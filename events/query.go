@@ -0,0 +1,209 @@
+package events
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// EventType is the type of an event, such as a forum post or a paste.
+type EventType string
+
+const (
+	TypeLeakedCredential EventType = "leaked_credential"
+	TypeForumPost        EventType = "forum_post"
+	TypeListing          EventType = "listing"
+	TypeChatMessage      EventType = "chat_message"
+	TypePaste            EventType = "paste"
+	TypeStealerLog       EventType = "stealer_log"
+	TypeRansomLeak       EventType = "ransomleak"
+	TypeLookalike        EventType = "lookalike"
+	TypeBlogPost         EventType = "blog_post"
+)
+
+// Severity is how important an event is.
+type Severity string
+
+const (
+	SeverityInfo     Severity = "info"
+	SeverityLow      Severity = "low"
+	SeverityMedium   Severity = "medium"
+	SeverityHigh     Severity = "high"
+	SeverityCritical Severity = "critical"
+)
+
+func (severity Severity) valid() bool {
+	switch severity {
+	case SeverityInfo, SeverityLow, SeverityMedium, SeverityHigh, SeverityCritical:
+		return true
+	}
+	return false
+}
+
+// Order is the order in which events are returned.
+type Order string
+
+const (
+	OrderAsc  Order = "asc"
+	OrderDesc Order = "desc"
+)
+
+// Query describes an event search.
+//
+// It is created with NewQuery and configured by chaining its methods.
+// It is validated when the search is performed.
+type Query struct {
+	text          string
+	types         []EventType
+	severities    []Severity
+	from          time.Time
+	to            time.Time
+	identifierIds []int64
+	order         Order
+	size          int
+}
+
+// NewQuery can be used to create a new Query that matches every event.
+func NewQuery() *Query {
+	return &Query{}
+}
+
+// Text allows matching events with a full-text query.
+func (query *Query) Text(text string) *Query {
+	query.text = text
+	return query
+}
+
+// Types allows matching only events of the given types.
+func (query *Query) Types(types ...EventType) *Query {
+	query.types = append(query.types, types...)
+	return query
+}
+
+// Severities allows matching only events of the given severities.
+func (query *Query) Severities(severities ...Severity) *Query {
+	query.severities = append(query.severities, severities...)
+	return query
+}
+
+// Since allows matching only events created at or after the given time.
+func (query *Query) Since(from time.Time) *Query {
+	query.from = from
+	return query
+}
+
+// Until allows matching only events created at or before the given time.
+func (query *Query) Until(to time.Time) *Query {
+	query.to = to
+	return query
+}
+
+// Identifiers allows matching only events that matched the identifiers
+// with the given ids. It is only supported by tenant searches.
+func (query *Query) Identifiers(identifierIds ...int64) *Query {
+	query.identifierIds = append(query.identifierIds, identifierIds...)
+	return query
+}
+
+// Order allows configuring the order of the events.
+func (query *Query) Order(order Order) *Query {
+	query.order = order
+	return query
+}
+
+// Size allows configuring the number of events per page.
+func (query *Query) Size(size int) *Query {
+	query.size = size
+	return query
+}
+
+// searchScope is the set of events that a search covers.
+type searchScope string
+
+const (
+	scopeTenant searchScope = "tenant"
+	scopeGlobal searchScope = "global"
+)
+
+func (scope searchScope) path() string {
+	return fmt.Sprintf("/firework/v4/events/%s/_search", scope)
+}
+
+func (query *Query) validate(scope searchScope) error {
+	if query == nil {
+		return errors.New("query is nil")
+	}
+	if scope == scopeGlobal && strings.TrimSpace(query.text) == "" {
+		return errors.New("global search requires a text query")
+	}
+	for _, eventType := range query.types {
+		if eventType == "" {
+			return errors.New("event type is empty")
+		}
+	}
+	for _, severity := range query.severities {
+		if !severity.valid() {
+			return fmt.Errorf("invalid severity: %q", severity)
+		}
+	}
+	if !query.from.IsZero() && !query.to.IsZero() && query.to.Before(query.from) {
+		return errors.New("date range must end after its start")
+	}
+	if len(query.identifierIds) > 0 && scope != scopeTenant {
+		return errors.New("identifier filters are only supported by tenant searches")
+	}
+	if query.order != "" && query.order != OrderAsc && query.order != OrderDesc {
+		return fmt.Errorf("invalid order: %q", query.order)
+	}
+	if query.size < 0 {
+		return fmt.Errorf("invalid size: %d", query.size)
+	}
+	return nil
+}
+
+// body validates the query and returns the body of the search.
+func (query *Query) body(scope searchScope) (map[string]interface{}, error) {
+	if err := query.validate(scope); err != nil {
+		return nil, fmt.Errorf("invalid query: %w", err)
+	}
+
+	body := map[string]interface{}{}
+	if query.text != "" {
+		body["query"] = map[string]interface{}{
+			"type":         "query_string",
+			"query_string": query.text,
+		}
+	}
+	if query.order != "" {
+		body["order"] = query.order
+	}
+	if query.size > 0 {
+		body["size"] = query.size
+	}
+
+	filters := map[string]interface{}{}
+	if len(query.types) > 0 {
+		filters["type"] = query.types
+	}
+	if len(query.severities) > 0 {
+		filters["severity"] = query.severities
+	}
+	if !query.from.IsZero() || !query.to.IsZero() {
+		dateRange := map[string]interface{}{}
+		if !query.from.IsZero() {
+			dateRange["gte"] = query.from
+		}
+		if !query.to.IsZero() {
+			dateRange["lte"] = query.to
+		}
+		filters["estimated_created_at"] = dateRange
+	}
+	if len(query.identifierIds) > 0 {
+		filters["identifier_ids"] = query.identifierIds
+	}
+	if len(filters) > 0 {
+		body["filters"] = filters
+	}
+	return body, nil
+}
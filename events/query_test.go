@@ -0,0 +1,70 @@
+package events
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQueryBody(t *testing.T) {
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	body, err := NewQuery().
+		Text("acme").
+		Types(TypeForumPost, TypePaste).
+		Severities(SeverityHigh, SeverityCritical).
+		Since(from).
+		Until(from.Add(time.Hour*24)).
+		Identifiers(12, 34).
+		Order(OrderDesc).
+		Size(50).
+		body(scopeTenant)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	encoded, err := json.Marshal(body)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{
+		"query": {"type": "query_string", "query_string": "acme"},
+		"order": "desc",
+		"size": 50,
+		"filters": {
+			"type": ["forum_post", "paste"],
+			"severity": ["high", "critical"],
+			"estimated_created_at": {
+				"gte": "2024-01-01T00:00:00Z",
+				"lte": "2024-01-02T00:00:00Z"
+			},
+			"identifier_ids": [12, 34]
+		}
+	}`, string(encoded))
+}
+
+func TestQueryBodyEmpty(t *testing.T) {
+	body, err := NewQuery().body(scopeTenant)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{}, body)
+}
+
+func TestQueryValidate(t *testing.T) {
+	now := time.Now()
+	for _, tc := range []struct {
+		query    *Query
+		scope    searchScope
+		expected string
+	}{
+		{NewQuery(), scopeGlobal, "global search requires a text query"},
+		{NewQuery().Types(""), scopeTenant, "event type is empty"},
+		{NewQuery().Severities("urgent"), scopeTenant, `invalid severity: "urgent"`},
+		{NewQuery().Since(now).Until(now.Add(-time.Hour)), scopeTenant, "date range must end after its start"},
+		{NewQuery().Text("acme").Identifiers(1), scopeGlobal, "only supported by tenant searches"},
+		{NewQuery().Order("random"), scopeTenant, `invalid order: "random"`},
+		{NewQuery().Size(-1), scopeTenant, "invalid size: -1"},
+		{nil, scopeTenant, "query is nil"},
+	} {
+		_, err := tc.query.body(tc.scope)
+		assert.ErrorContains(t, err, tc.expected)
+	}
+}
@@ -0,0 +1,108 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/Flared/go-flareio"
+)
+
+// MatchedIdentifier is an identifier of the tenant that an event matched.
+type MatchedIdentifier struct {
+	Id   int64  `json:"id"`
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+// Metadata is the metadata shared by all events.
+type Metadata struct {
+	Uid      string    `json:"uid"`
+	Type     EventType `json:"type"`
+	Severity Severity  `json:"severity"`
+
+	// EstimatedCreatedAt is when the event is estimated to have
+	// happened, if known.
+	EstimatedCreatedAt *time.Time `json:"estimated_created_at"`
+
+	// MaterializedAt is when the event was collected by Flare.
+	MaterializedAt *time.Time `json:"materialized_at"`
+
+	// MatchedIdentifiers are only set by tenant searches.
+	MatchedIdentifiers []MatchedIdentifier `json:"matched_identifiers"`
+}
+
+// Summary is an event returned by a search.
+type Summary struct {
+	Metadata Metadata `json:"metadata"`
+
+	// Highlights are the excerpts of the fields that matched the text
+	// of the query, by field.
+	Highlights map[string][]string `json:"highlights"`
+}
+
+func decodeSummary(item json.RawMessage) (*Summary, error) {
+	var summary Summary
+	if err := json.Unmarshal(item, &summary); err != nil {
+		return nil, fmt.Errorf("failed to decode event summary: %w", err)
+	}
+	return &summary, nil
+}
+
+// SummariesPager allows fetching the events of a search one page
+// at a time.
+type SummariesPager struct {
+	pager *flareio.Pager
+}
+
+// PagerTenantEvents returns a pager over the events of the tenant
+// feed that match the query.
+func (client *Client) PagerTenantEvents(
+	query *Query,
+	optionFns ...flareio.IterOption,
+) (*SummariesPager, error) {
+	return client.pagerEvents(scopeTenant, query, optionFns)
+}
+
+// PagerGlobalEvents returns a pager over all the events that match
+// the query.
+func (client *Client) PagerGlobalEvents(
+	query *Query,
+	optionFns ...flareio.IterOption,
+) (*SummariesPager, error) {
+	return client.pagerEvents(scopeGlobal, query, optionFns)
+}
+
+func (client *Client) pagerEvents(
+	scope searchScope,
+	query *Query,
+	optionFns []flareio.IterOption,
+) (*SummariesPager, error) {
+	body, err := query.body(scope)
+	if err != nil {
+		return nil, err
+	}
+	return &SummariesPager{
+		pager: client.apiClient.PagerPostJson(
+			scope.path(),
+			nil,
+			body,
+			optionFns...,
+		),
+	}, nil
+}
+
+// HasMore returns whether there are pages left to fetch.
+func (pager *SummariesPager) HasMore() bool {
+	return pager.pager.HasMore()
+}
+
+// Next fetches the events of the next page.
+//
+// It behaves like flareio.Pager.Next. Items that cannot be decoded
+// are reported with an *flareio.ItemErrors along with the others,
+// and the pager continues.
+func (pager *SummariesPager) Next(ctx context.Context) ([]*Summary, error) {
+	return flareio.NextDecoded(ctx, pager.pager, decodeSummary)
+}
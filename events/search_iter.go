@@ -0,0 +1,55 @@
+//go:build go1.23
+
+package events
+
+import (
+	"iter"
+
+	"github.com/Flared/go-flareio"
+)
+
+// IterTenantEvents allows to iterate over the events of the tenant
+// feed that match the query.
+//
+// An event that cannot be decoded is yielded as an error, and the
+// iteration continues if the consumer doesn't stop.
+func (client *Client) IterTenantEvents(
+	query *Query,
+	optionFns ...flareio.IterOption,
+) iter.Seq2[*Summary, error] {
+	return client.iterEvents(scopeTenant, query, optionFns)
+}
+
+// IterGlobalEvents allows to iterate over all the events that match
+// the query.
+//
+// It behaves like IterTenantEvents.
+func (client *Client) IterGlobalEvents(
+	query *Query,
+	optionFns ...flareio.IterOption,
+) iter.Seq2[*Summary, error] {
+	return client.iterEvents(scopeGlobal, query, optionFns)
+}
+
+func (client *Client) iterEvents(
+	scope searchScope,
+	query *Query,
+	optionFns []flareio.IterOption,
+) iter.Seq2[*Summary, error] {
+	return func(yield func(*Summary, error) bool) {
+		body, err := query.body(scope)
+		if err != nil {
+			yield(nil, err)
+			return
+		}
+		flareio.IterDecoded(
+			client.apiClient.IterPostJsonItems(
+				scope.path(),
+				nil,
+				body,
+				optionFns...,
+			),
+			decodeSummary,
+		)(yield)
+	}
+}
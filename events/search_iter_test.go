@@ -0,0 +1,36 @@
+//go:build go1.23
+
+package events
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIterGlobalEvents(t *testing.T) {
+	server := newSearchTest(t, "/firework/v4/events/global/_search")
+	defer server.Close()
+
+	uids := []string{}
+	for summary, err := range NewClient(server.Client()).IterGlobalEvents(
+		NewQuery().Text("acme"),
+	) {
+		if len(uids) > 5 {
+			// We are going crazy here...
+			break
+		}
+		if !assert.NoError(t, err, "iter yielded an error") {
+			break
+		}
+		uids = append(uids, summary.Metadata.Uid)
+	}
+
+	assert.Equal(t, []string{"forum_post/1", "paste/2"}, uids)
+}
+
+func TestIterTenantEventsInvalidQuery(t *testing.T) {
+	for _, err := range NewClient(nil).IterTenantEvents(NewQuery().Size(-1)) {
+		assert.ErrorContains(t, err, "invalid size")
+	}
+}
@@ -0,0 +1,98 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/Flared/go-flareio/flareiotest"
+	"github.com/stretchr/testify/assert"
+)
+
+// newSearchTest serves two pages of events.
+func newSearchTest(t *testing.T, expectedPath string) *flareiotest.Server {
+	return flareiotest.NewServer(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, expectedPath, r.URL.Path)
+
+			var body map[string]interface{}
+			if err := json.NewDecoder(r.Body).Decode(&body); !assert.NoError(t, err, "Error decoding posted JSON") {
+				return
+			}
+			assert.Equal(
+				t,
+				map[string]interface{}{"type": "query_string", "query_string": "acme"},
+				body["query"],
+			)
+
+			if body["from"] == nil {
+				w.Write([]byte(`{"next": "second-page", "items": [{
+					"metadata": {
+						"uid": "forum_post/1",
+						"type": "forum_post",
+						"severity": "high",
+						"estimated_created_at": "2024-01-01T10:00:00Z",
+						"matched_identifiers": [{"id": 12, "name": "acme.com", "type": "domain"}]
+					},
+					"highlights": {"content": ["selling <mark>acme</mark> access"]}
+				}]}`))
+			} else {
+				w.Write([]byte(`{"next": null, "items": [{
+					"metadata": {"uid": "paste/2", "type": "paste", "severity": "low"}
+				}]}`))
+			}
+		}),
+	)
+}
+
+func TestPagerTenantEvents(t *testing.T) {
+	server := newSearchTest(t, "/firework/v4/events/tenant/_search")
+	defer server.Close()
+
+	pager, err := NewClient(server.Client()).PagerTenantEvents(
+		NewQuery().Text("acme"),
+	)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	summaries := []*Summary{}
+	for pager.HasMore() {
+		if len(summaries) > 5 {
+			// We are going crazy here...
+			break
+		}
+		page, err := pager.Next(context.Background())
+		if !assert.NoError(t, err, "pager returned an error") {
+			return
+		}
+		summaries = append(summaries, page...)
+	}
+
+	if !assert.Len(t, summaries, 2) {
+		return
+	}
+	createdAt := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	assert.Equal(t, &Summary{
+		Metadata: Metadata{
+			Uid:                "forum_post/1",
+			Type:               TypeForumPost,
+			Severity:           SeverityHigh,
+			EstimatedCreatedAt: &createdAt,
+			MatchedIdentifiers: []MatchedIdentifier{
+				{Id: 12, Name: "acme.com", Type: "domain"},
+			},
+		},
+		Highlights: map[string][]string{
+			"content": {"selling <mark>acme</mark> access"},
+		},
+	}, summaries[0])
+	assert.Equal(t, "paste/2", summaries[1].Metadata.Uid)
+}
+
+func TestPagerGlobalEventsInvalidQuery(t *testing.T) {
+	_, err := NewClient(nil).PagerGlobalEvents(NewQuery())
+	assert.ErrorContains(t, err, "invalid query: global search requires a text query")
+}
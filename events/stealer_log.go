@@ -0,0 +1,242 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Flared/go-flareio"
+)
+
+// stealerLogsQuery returns a copy of the query that only matches
+// stealer logs.
+func stealerLogsQuery(query *Query) *Query {
+	if query == nil {
+		return nil
+	}
+	stealerQuery := *query
+	stealerQuery.types = []EventType{TypeStealerLog}
+	return &stealerQuery
+}
+
+// asStealerLog returns the event as a stealer log, or an error if the
+// event is of another type.
+func asStealerLog(event Event) (*StealerLog, error) {
+	stealerLog, ok := event.(*StealerLog)
+	if !ok {
+		return nil, fmt.Errorf(
+			"event %q is a %s, not a stealer log",
+			event.EventMetadata().Uid,
+			event.EventMetadata().Type,
+		)
+	}
+	return stealerLog, nil
+}
+
+// StealerLogsPager allows fetching the stealer logs of the tenant one
+// page at a time.
+type StealerLogsPager struct {
+	client    *Client
+	summaries *SummariesPager
+}
+
+// PagerStealerLogs returns a pager over the stealer logs of the tenant
+// feed that match the query, which is restricted to stealer logs.
+//
+// The details of the stealer logs of each page are fetched one at a
+// time. IterStealerLogs fetches them concurrently.
+func (client *Client) PagerStealerLogs(
+	query *Query,
+	optionFns ...flareio.IterOption,
+) (*StealerLogsPager, error) {
+	summaries, err := client.PagerTenantEvents(stealerLogsQuery(query), optionFns...)
+	if err != nil {
+		return nil, err
+	}
+	return &StealerLogsPager{
+		client:    client,
+		summaries: summaries,
+	}, nil
+}
+
+// HasMore returns whether there are pages left to fetch.
+func (pager *StealerLogsPager) HasMore() bool {
+	return pager.summaries.HasMore()
+}
+
+// Next fetches the details of the stealer logs of the next page.
+//
+// It behaves like flareio.Pager.Next. Stealer logs that could not be
+// decoded or whose details could not be fetched don't stop the pager:
+// the other stealer logs of the page are returned along with a
+// *flareio.ItemErrors. Errors about details wrap an *EnrichError.
+func (pager *StealerLogsPager) Next(ctx context.Context) ([]*StealerLog, error) {
+	summaries, err := pager.summaries.Next(ctx)
+	itemErrs := &flareio.ItemErrors{}
+	if err != nil && !errors.As(err, &itemErrs) {
+		return nil, err
+	}
+
+	// Summaries that could not be decoded are missing from the page, so
+	// the index of each summary is found by skipping their errors.
+	decodeErrs := itemErrs.Errors
+	enrichErrs := []*flareio.ItemError{}
+	stealerLogs := []*StealerLog{}
+	index := 0
+	for _, summary := range summaries {
+		for len(decodeErrs) > 0 && decodeErrs[0].Index == index {
+			decodeErrs = decodeErrs[1:]
+			index = index + 1
+		}
+		if stealerLog, err := pager.fetchStealerLog(ctx, summary.Metadata.Uid); err != nil {
+			enrichErrs = append(enrichErrs, &flareio.ItemError{
+				Index: index,
+				Err:   err,
+			})
+		} else {
+			stealerLogs = append(stealerLogs, stealerLog)
+		}
+		index = index + 1
+	}
+
+	if len(enrichErrs) > 0 {
+		itemErrs.Errors = append(itemErrs.Errors, enrichErrs...)
+		sort.SliceStable(itemErrs.Errors, func(i, j int) bool {
+			return itemErrs.Errors[i].Index < itemErrs.Errors[j].Index
+		})
+	}
+	if len(itemErrs.Errors) > 0 {
+		return stealerLogs, itemErrs
+	}
+	return stealerLogs, nil
+}
+
+// fetchStealerLog fetches the details of a stealer log, and returns an
+// *EnrichError if they could not be fetched.
+func (pager *StealerLogsPager) fetchStealerLog(ctx context.Context, uid string) (*StealerLog, error) {
+	event, err := pager.client.GetEvent(ctx, uid)
+	if err != nil {
+		return nil, &EnrichError{
+			Uid: uid,
+			Err: err,
+		}
+	}
+	stealerLog, err := asStealerLog(event)
+	if err != nil {
+		return nil, &EnrichError{
+			Uid: uid,
+			Err: err,
+		}
+	}
+	return stealerLog, nil
+}
+
+// AffectedDomains returns the domains exposed by the stealer log: its
+// domains, and the domains of its credentials and cookies. They are
+// lowercased and sorted.
+func (stealerLog *StealerLog) AffectedDomains() []string {
+	seen := map[string]struct{}{}
+	add := func(domain string) {
+		domain = strings.ToLower(strings.Trim(domain, "."))
+		if domain != "" {
+			seen[domain] = struct{}{}
+		}
+	}
+	for _, domain := range stealerLog.Domains {
+		add(domain)
+	}
+	for _, credential := range stealerLog.Credentials {
+		if parsed, err := url.Parse(credential.Url); err == nil {
+			add(parsed.Hostname())
+		}
+	}
+	for _, cookie := range stealerLog.Cookies {
+		add(cookie.Domain)
+	}
+
+	domains := make([]string, 0, len(seen))
+	for domain := range seen {
+		domains = append(domains, domain)
+	}
+	sort.Strings(domains)
+	return domains
+}
+
+// IdentityExposure is what stealer logs expose of an identity, such as
+// the email of an employee.
+type IdentityExposure struct {
+	// Identity is the lowercased username of the credentials.
+	Identity string
+
+	// Credentials are the credentials of the identity, in the order of
+	// the stealer logs.
+	Credentials []StealerLogCredential
+
+	// StealerLogs are the stealer logs that expose the identity.
+	StealerLogs []*StealerLog
+
+	// LastInfectedAt is the most recent infection of the stealer logs,
+	// if known.
+	LastInfectedAt *time.Time
+}
+
+// GroupByIdentity groups the credentials of the stealer logs by the
+// identity that they belong to, so that each identity can be handled
+// separately.
+//
+// If domains are given, only the identities that are emails at one of
+// them are kept. Identities are sorted.
+func GroupByIdentity(stealerLogs []*StealerLog, domains ...string) []*IdentityExposure {
+	wantedDomains := map[string]struct{}{}
+	for _, domain := range domains {
+		wantedDomains[strings.ToLower(domain)] = struct{}{}
+	}
+
+	exposures := map[string]*IdentityExposure{}
+	for _, stealerLog := range stealerLogs {
+		for _, credential := range stealerLog.Credentials {
+			identity := strings.ToLower(strings.TrimSpace(credential.Username))
+			if identity == "" {
+				continue
+			}
+			if len(wantedDomains) > 0 {
+				at := strings.LastIndex(identity, "@")
+				if at < 0 {
+					continue
+				}
+				if _, wanted := wantedDomains[identity[at+1:]]; !wanted {
+					continue
+				}
+			}
+
+			exposure, exists := exposures[identity]
+			if !exists {
+				exposure = &IdentityExposure{
+					Identity: identity,
+				}
+				exposures[identity] = exposure
+			}
+			exposure.Credentials = append(exposure.Credentials, credential)
+			if len(exposure.StealerLogs) == 0 || exposure.StealerLogs[len(exposure.StealerLogs)-1] != stealerLog {
+				exposure.StealerLogs = append(exposure.StealerLogs, stealerLog)
+			}
+			infectedAt := stealerLog.InfectedAt
+			if infectedAt != nil && (exposure.LastInfectedAt == nil || infectedAt.After(*exposure.LastInfectedAt)) {
+				exposure.LastInfectedAt = infectedAt
+			}
+		}
+	}
+
+	grouped := make([]*IdentityExposure, 0, len(exposures))
+	for _, exposure := range exposures {
+		grouped = append(grouped, exposure)
+	}
+	sort.Slice(grouped, func(i, j int) bool {
+		return grouped[i].Identity < grouped[j].Identity
+	})
+	return grouped
+}
@@ -0,0 +1,52 @@
+//go:build go1.23
+
+package events
+
+import (
+	"context"
+	"iter"
+
+	"github.com/Flared/go-flareio"
+)
+
+// IterStealerLogs allows to iterate over the details of the stealer
+// logs of the tenant feed that match the query, which is restricted to
+// stealer logs.
+//
+// The details of up to parallelism stealer logs are fetched
+// concurrently, and errors are yielded like IterEnrichedEvents does.
+// The search uses ctx unless optionFns configure another context.
+// Like with IterEnrichedEvents, checkpointers and follow mode are not
+// supported.
+func (client *Client) IterStealerLogs(
+	ctx context.Context,
+	query *Query,
+	parallelism int,
+	optionFns ...flareio.IterOption,
+) iter.Seq2[*StealerLog, error] {
+	optionFns = append([]flareio.IterOption{flareio.WithContext(ctx)}, optionFns...)
+	return func(yield func(*StealerLog, error) bool) {
+		for event, err := range client.IterEnrichedEvents(
+			ctx,
+			client.IterTenantEvents(stealerLogsQuery(query), optionFns...),
+			parallelism,
+		) {
+			if err != nil {
+				if !yield(nil, err) {
+					return
+				}
+				continue
+			}
+			stealerLog, err := asStealerLog(event)
+			if err != nil {
+				err = &EnrichError{
+					Uid: event.EventMetadata().Uid,
+					Err: err,
+				}
+			}
+			if !yield(stealerLog, err) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,76 @@
+//go:build go1.23
+
+package events
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/Flared/go-flareio/flareiotest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIterStealerLogs(t *testing.T) {
+	server := newStealerLogsTest(t)
+	defer server.Close()
+
+	client := NewClient(server.Client())
+	stealerLogs := []*StealerLog{}
+	for stealerLog, err := range client.IterStealerLogs(
+		context.Background(),
+		NewQuery().Identifiers(12),
+		2,
+	) {
+		if !assert.NoError(t, err, "iter yielded an error") {
+			break
+		}
+		stealerLogs = append(stealerLogs, stealerLog)
+	}
+
+	if !assert.Len(t, stealerLogs, 2) {
+		return
+	}
+	assert.Equal(t, "stealer_log/1", stealerLogs[0].Metadata.Uid)
+	assert.Equal(t, "stealer_log/2", stealerLogs[1].Metadata.Uid)
+
+	grouped := GroupByIdentity(stealerLogs, "example.com")
+	if assert.Len(t, grouped, 1) {
+		assert.Equal(t, "john@example.com", grouped[0].Identity)
+		assert.Equal(t, stealerLogs[1].InfectedAt, grouped[0].LastInfectedAt)
+	}
+}
+
+func TestIterStealerLogsInvalidQuery(t *testing.T) {
+	client := NewClient(nil)
+	for _, err := range client.IterStealerLogs(context.Background(), nil, 1) {
+		assert.EqualError(t, err, "invalid query: query is nil")
+	}
+}
+
+func TestIterStealerLogsContext(t *testing.T) {
+	searched := false
+	server := flareiotest.NewServer(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			searched = true
+			w.Write([]byte(`{"next": null, "items": []}`))
+		}),
+	)
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	client := NewClient(server.Client())
+	errs := 0
+	for _, err := range client.IterStealerLogs(ctx, NewQuery(), 1) {
+		if errs > 5 {
+			// We are going crazy here...
+			break
+		}
+		assert.ErrorIs(t, err, context.Canceled)
+		errs = errs + 1
+	}
+	assert.Equal(t, 1, errs)
+	assert.False(t, searched, "the search should use the context")
+}
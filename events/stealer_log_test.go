@@ -0,0 +1,246 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Flared/go-flareio"
+	"github.com/Flared/go-flareio/flareiotest"
+	"github.com/stretchr/testify/assert"
+)
+
+// newStealerLogsTest serves a search that returns stealer_log/1 and
+// stealer_log/2 on two pages, and the details of stealer logs.
+func newStealerLogsTest(t *testing.T) *flareiotest.Server {
+	return flareiotest.NewServer(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/firework/v4/events/tenant/_search" {
+				var body map[string]interface{}
+				if !assert.NoError(t, json.NewDecoder(r.Body).Decode(&body)) {
+					return
+				}
+				assert.Equal(
+					t,
+					map[string]interface{}{
+						"type":           []interface{}{"stealer_log"},
+						"identifier_ids": []interface{}{float64(12)},
+					},
+					body["filters"],
+				)
+				if body["from"] == nil {
+					w.Write([]byte(`{"next": "second-page", "items": [{"metadata": {"uid": "stealer_log/1", "type": "stealer_log"}}]}`))
+				} else {
+					w.Write([]byte(`{"next": null, "items": [{"metadata": {"uid": "stealer_log/2", "type": "stealer_log"}}]}`))
+				}
+				return
+			}
+
+			uid := strings.TrimPrefix(r.URL.Path, "/firework/v4/events/")
+			eventType := strings.Split(uid, "/")[0]
+			w.Write([]byte(`{
+				"metadata": {"uid": "` + uid + `", "type": "` + eventType + `"},
+				"data": {
+					"malware_family": "redline",
+					"hostname": "DESKTOP-` + uid[len(uid)-1:] + `",
+					"infected_at": "2024-03-0` + uid[len(uid)-1:] + `T00:00:00Z",
+					"credentials": [
+						{"url": "https://sso.example.com/login", "username": "John@example.com", "password": "hunter2"}
+					]
+				}
+			}`))
+		}),
+	)
+}
+
+func TestDecodeStealerLog(t *testing.T) {
+	event, err := DecodeEvent([]byte(`{
+		"metadata": {"uid": "stealer_log/1", "type": "stealer_log"},
+		"data": {
+			"malware_family": "lumma",
+			"hostname": "DESKTOP-1",
+			"ip": "203.0.113.4",
+			"country": "CA",
+			"operating_system": "Windows 10 Pro",
+			"computer_username": "jdoe",
+			"hwid": "ABC123",
+			"infected_at": "2024-03-01T12:00:00Z",
+			"domains": ["example.com"],
+			"credentials": [{"url": "https://vpn.example.com", "username": "jdoe@example.com", "password": "secret"}],
+			"cookies": [{"domain": ".example.com", "name": "session", "path": "/", "expires_at": "2025-01-01T00:00:00Z"}]
+		}
+	}`))
+	if !assert.NoError(t, err) {
+		return
+	}
+	stealerLog := event.(*StealerLog)
+	infectedAt := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	expiresAt := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	assert.Equal(t, &StealerLog{
+		Metadata:         Metadata{Uid: "stealer_log/1", Type: TypeStealerLog},
+		MalwareFamily:    "lumma",
+		Hostname:         "DESKTOP-1",
+		Ip:               "203.0.113.4",
+		Country:          "CA",
+		OperatingSystem:  "Windows 10 Pro",
+		ComputerUsername: "jdoe",
+		Hwid:             "ABC123",
+		InfectedAt:       &infectedAt,
+		Domains:          []string{"example.com"},
+		Credentials: []StealerLogCredential{
+			{Url: "https://vpn.example.com", Username: "jdoe@example.com", Password: "secret"},
+		},
+		Cookies: []StealerLogCookie{
+			{Domain: ".example.com", Name: "session", Path: "/", ExpiresAt: &expiresAt},
+		},
+	}, stealerLog)
+}
+
+func TestStealerLogAffectedDomains(t *testing.T) {
+	stealerLog := &StealerLog{
+		Domains: []string{"Example.com"},
+		Credentials: []StealerLogCredential{
+			{Url: "https://vpn.example.com:8443/login"},
+			{Url: "android://app"},
+			{Url: ""},
+		},
+		Cookies: []StealerLogCookie{
+			{Domain: ".example.com"},
+			{Domain: "mail.example.org"},
+		},
+	}
+	assert.Equal(
+		t,
+		[]string{"app", "example.com", "mail.example.org", "vpn.example.com"},
+		stealerLog.AffectedDomains(),
+	)
+}
+
+func TestGroupByIdentity(t *testing.T) {
+	firstInfection := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	secondInfection := time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)
+	laptop := &StealerLog{
+		Hostname:   "LAPTOP",
+		InfectedAt: &firstInfection,
+		Credentials: []StealerLogCredential{
+			{Url: "https://sso.example.com", Username: "John@Example.com"},
+			{Url: "https://vpn.example.com", Username: "john@example.com"},
+			{Url: "https://shop.example.net", Username: "johnny"},
+			{Url: "https://mail.example.com", Username: " "},
+		},
+	}
+	desktop := &StealerLog{
+		Hostname:   "DESKTOP",
+		InfectedAt: &secondInfection,
+		Credentials: []StealerLogCredential{
+			{Url: "https://sso.example.com", Username: "jane@example.com"},
+			{Url: "https://sso.example.com", Username: "john@example.com"},
+			{Url: "https://forum.example.net", Username: "jane@personal.example.net"},
+		},
+	}
+
+	grouped := GroupByIdentity([]*StealerLog{laptop, desktop}, "EXAMPLE.com")
+	if !assert.Len(t, grouped, 2) {
+		return
+	}
+	assert.Equal(t, "jane@example.com", grouped[0].Identity)
+	assert.Equal(t, []*StealerLog{desktop}, grouped[0].StealerLogs)
+
+	john := grouped[1]
+	assert.Equal(t, "john@example.com", john.Identity)
+	assert.Len(t, john.Credentials, 3)
+	assert.Equal(t, []*StealerLog{laptop, desktop}, john.StealerLogs)
+	assert.Equal(t, &secondInfection, john.LastInfectedAt)
+
+	identities := []string{}
+	for _, exposure := range GroupByIdentity([]*StealerLog{laptop, desktop}) {
+		identities = append(identities, exposure.Identity)
+	}
+	assert.Equal(
+		t,
+		[]string{"jane@example.com", "jane@personal.example.net", "john@example.com", "johnny"},
+		identities,
+	)
+}
+
+func TestPagerStealerLogs(t *testing.T) {
+	server := newStealerLogsTest(t)
+	defer server.Close()
+
+	client := NewClient(server.Client())
+	pager, err := client.PagerStealerLogs(NewQuery().Types(TypePaste).Identifiers(12))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	hostnames := []string{}
+	for pager.HasMore() {
+		if len(hostnames) > 5 {
+			// We are going crazy here...
+			break
+		}
+		stealerLogs, err := pager.Next(context.Background())
+		if !assert.NoError(t, err, "pager returned an error") {
+			return
+		}
+		for _, stealerLog := range stealerLogs {
+			hostnames = append(hostnames, stealerLog.Hostname)
+		}
+	}
+	assert.Equal(t, []string{"DESKTOP-1", "DESKTOP-2"}, hostnames)
+
+	_, err = client.PagerStealerLogs(nil)
+	assert.EqualError(t, err, "invalid query: query is nil")
+}
+
+func TestPagerStealerLogsItemErrors(t *testing.T) {
+	server := flareiotest.NewServer(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch r.URL.Path {
+			case "/firework/v4/events/tenant/_search":
+				w.Write([]byte(`{"next": null, "items": [
+					{"metadata": {"uid": "stealer_log/1", "type": "stealer_log"}},
+					"not a summary",
+					{"metadata": {"uid": "stealer_log/missing", "type": "stealer_log"}},
+					{"metadata": {"uid": "stealer_log/3", "type": "stealer_log"}}
+				]}`))
+			case "/firework/v4/events/stealer_log/missing":
+				w.WriteHeader(http.StatusNotFound)
+			default:
+				uid := strings.TrimPrefix(r.URL.Path, "/firework/v4/events/")
+				w.Write([]byte(`{"metadata": {"uid": "` + uid + `", "type": "stealer_log"}, "data": {}}`))
+			}
+		}),
+	)
+	defer server.Close()
+
+	client := NewClient(server.Client())
+	pager, err := client.PagerStealerLogs(NewQuery())
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	stealerLogs, err := pager.Next(context.Background())
+	uids := []string{}
+	for _, stealerLog := range stealerLogs {
+		uids = append(uids, stealerLog.Metadata.Uid)
+	}
+	assert.Equal(t, []string{"stealer_log/1", "stealer_log/3"}, uids, "the other stealer logs should be returned")
+
+	var itemErrs *flareio.ItemErrors
+	if assert.ErrorAs(t, err, &itemErrs) && assert.Len(t, itemErrs.Errors, 2) {
+		assert.Equal(t, 1, itemErrs.Errors[0].Index)
+		assert.ErrorContains(t, itemErrs.Errors[0], "failed to decode event summary")
+
+		assert.Equal(t, 2, itemErrs.Errors[1].Index)
+		var enrichErr *EnrichError
+		if assert.ErrorAs(t, itemErrs.Errors[1], &enrichErr) {
+			assert.Equal(t, "stealer_log/missing", enrichErr.Uid)
+			assert.ErrorIs(t, enrichErr, ErrEventNotFound)
+		}
+	}
+	assert.False(t, pager.HasMore())
+}
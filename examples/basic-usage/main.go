@@ -1,27 +1,42 @@
 package main
 
 import (
+	"context"
 	"fmt"
-	"io"
 	"os"
 
 	"github.com/Flared/go-flareio"
 )
 
 func main() {
+	ctx := context.Background()
 	client := flareio.NewApiClient(
 		os.Getenv("FLARE_API_KEY"),
 	)
-	resp, err := client.Get(
-		"/tokens/test", nil,
-	)
+
+	identity, err := client.WhoAmI(ctx)
 	if err != nil {
 		fmt.Printf("failed to test token: %s\n", err)
 		os.Exit(1)
 	}
-	defer resp.Body.Close()
-	if _, err := io.Copy(os.Stdout, resp.Body); err != nil {
-		fmt.Printf("failed to print response: %s\n", err)
+	fmt.Printf(
+		"Authenticated as %s in organization %s, tenant %d (%s).\n",
+		identity.UserEmail,
+		identity.OrganizationName,
+		identity.TenantId,
+		identity.TenantName,
+	)
+	if err := identity.RequireScopes("firework"); err != nil {
+		fmt.Printf("cannot run: %s\n", err)
+		os.Exit(1)
+	}
+
+	tenants, err := client.ListTenants(ctx)
+	if err != nil {
+		fmt.Printf("failed to list tenants: %s\n", err)
 		os.Exit(1)
 	}
+	for _, tenant := range tenants {
+		fmt.Printf("Tenant %d: %s\n", tenant.Id, tenant.Name)
+	}
 }
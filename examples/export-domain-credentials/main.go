@@ -3,80 +3,57 @@
 package main
 
 import (
+	"context"
 	"encoding/csv"
-	"encoding/json"
 	"fmt"
 	"os"
 	"strconv"
 	"time"
 
 	"github.com/Flared/go-flareio"
+	"github.com/Flared/go-flareio/leaksdb"
 )
 
-type CredentialsResponse struct {
-	Items []Credential `json:"items"`
-}
-
-type CredentialSource struct {
-	Id string `json:"id"`
-}
-
-type Credential struct {
-	Id           int               `json:"id"`
-	Source       *CredentialSource `json:"source"`
-	IdentityName string            `json:"identity_name"`
-	Hash         string            `json:"hash"`
-}
-
 func exportDomainCredentials(
-	client *flareio.ApiClient,
+	client *leaksdb.Client,
 	domain string,
 ) error {
 	csvWriter := csv.NewWriter(os.Stdout)
+	sourceResolver := client.NewSourceResolver()
 
-	for result, err := range client.IterPostJson(
-		"/leaksdb/v2/credentials/_search",
-		nil,
-		map[string]interface{}{
-			"query": map[string]string{
-				"type": "domain",
-				"fqdn": domain,
-			},
-		},
+	for credential, err := range client.IterCredentials(
+		leaksdb.DomainQuery(domain),
 	) {
-		// Rate Limiting
-		time.Sleep(time.Second * 1)
-
 		if err != nil {
-			return fmt.Errorf("failed to fetch page: %w", err)
-		}
-
-		var credentialsResponse CredentialsResponse
-		if err := json.NewDecoder(result.Response.Body).Decode(&credentialsResponse); err != nil {
-			return fmt.Errorf("failed to decode response: %w", err)
+			return fmt.Errorf("failed to fetch credentials: %w", err)
 		}
 
-		for _, credential := range credentialsResponse.Items {
-			if err := csvWriter.Write(
-				[]string{
-					strconv.Itoa(credential.Id),
-					credential.Source.Id,
-					credential.IdentityName,
-					credential.Hash,
-				},
-			); err != nil {
-				return fmt.Errorf("failed to output record: %w", err)
-			}
+		// Sources that can't be resolved are exported by their id.
+		sourceName := credential.Source.Id
+		source, err := sourceResolver.Resolve(context.Background(), credential.Source.Id)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "failed to resolve source %q: %s\n", credential.Source.Id, err)
+		} else {
+			sourceName = source.Name
 		}
 
-		csvWriter.Flush()
-		if err := csvWriter.Error(); err != nil {
-			return fmt.Errorf("failed to flush writer: %w", err)
+		if err := csvWriter.Write(
+			[]string{
+				strconv.FormatInt(credential.Id, 10),
+				credential.Source.Id,
+				sourceName,
+				credential.IdentityName,
+				credential.Hash,
+				string(credential.HashType),
+			},
+		); err != nil {
+			return fmt.Errorf("failed to output record: %w", err)
 		}
+	}
 
-		if err := result.Response.Body.Close(); err != nil {
-			return fmt.Errorf("failed to close response: %w", err)
-		}
+	csvWriter.Flush()
+	if err := csvWriter.Error(); err != nil {
+		return fmt.Errorf("failed to flush writer: %w", err)
 	}
 
 	return nil
@@ -85,8 +62,13 @@ func exportDomainCredentials(
 func main() {
 	client := flareio.NewApiClient(
 		os.Getenv("FLARE_API_KEY"),
+		// Rate Limiting
+		flareio.WithRequestInterval(time.Second*1),
 	)
-	if err := exportDomainCredentials(client, "scatterholt.com"); err != nil {
+	if err := exportDomainCredentials(
+		leaksdb.NewClient(client),
+		"scatterholt.com",
+	); err != nil {
 		fmt.Println(err)
 		os.Exit(1)
 	}
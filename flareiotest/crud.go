@@ -0,0 +1,186 @@
+package flareiotest
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// CrudApi is a fake of the management endpoints of the Flare API, such
+// as those of identifiers or alert channels, that stores their objects
+// in memory as JSON objects. It is an http.Handler for NewServer.
+//
+// A collection path lists its objects with GET and creates an object
+// with POST. The path of an object, made of the path of its collection
+// and of its id, gets the object with GET, replaces it with PUT, updates
+// some of its fields with PATCH and deletes it with DELETE. A POST below
+// the path of an object, such as an action, is accepted.
+type CrudApi struct {
+	// PageSize is the number of objects listed per page. All of them
+	// are listed on one page if it is zero.
+	PageSize int
+
+	mu          sync.Mutex
+	nextId      int64
+	collections map[string]map[int64]map[string]interface{}
+	requests    []string
+}
+
+// NewCrudApi can be used to create a new CrudApi that serves the
+// collections at the given paths.
+func NewCrudApi(collectionPaths ...string) *CrudApi {
+	api := &CrudApi{
+		nextId:      1,
+		collections: make(map[string]map[int64]map[string]interface{}),
+	}
+	for _, path := range collectionPaths {
+		api.collections[path] = make(map[int64]map[string]interface{})
+	}
+	return api
+}
+
+// Requests returns the method and path of the requests served so far,
+// such as "GET /firework/v3/identifiers/1".
+func (api *CrudApi) Requests() []string {
+	api.mu.Lock()
+	defer api.mu.Unlock()
+	return append([]string(nil), api.requests...)
+}
+
+// ResetRequests forgets the requests served so far.
+func (api *CrudApi) ResetRequests() {
+	api.mu.Lock()
+	defer api.mu.Unlock()
+	api.requests = nil
+}
+
+// Objects returns the objects of the collection at the given path,
+// ordered by id.
+func (api *CrudApi) Objects(collectionPath string) []map[string]interface{} {
+	api.mu.Lock()
+	defer api.mu.Unlock()
+	return api.sortedObjects(api.collections[collectionPath])
+}
+
+func (api *CrudApi) sortedObjects(collection map[int64]map[string]interface{}) []map[string]interface{} {
+	ids := make([]int64, 0, len(collection))
+	for id := range collection {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	objects := make([]map[string]interface{}, 0, len(ids))
+	for _, id := range ids {
+		objects = append(objects, collection[id])
+	}
+	return objects
+}
+
+func (api *CrudApi) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	api.mu.Lock()
+	defer api.mu.Unlock()
+	api.requests = append(api.requests, r.Method+" "+r.URL.Path)
+
+	var collection map[int64]map[string]interface{}
+	var rest string
+	for path, objects := range api.collections {
+		if r.URL.Path == path || strings.HasPrefix(r.URL.Path, path+"/") {
+			collection = objects
+			rest = strings.TrimPrefix(strings.TrimPrefix(r.URL.Path, path), "/")
+		}
+	}
+	if collection == nil {
+		writeMessage(w, http.StatusNotFound, "not found")
+		return
+	}
+
+	var body map[string]interface{}
+	idPart, action, _ := strings.Cut(rest, "/")
+	if action == "" && (r.Method == http.MethodPost || r.Method == http.MethodPut || r.Method == http.MethodPatch) {
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil || body == nil {
+			writeMessage(w, http.StatusBadRequest, "body must be a JSON object")
+			return
+		}
+	}
+
+	if rest == "" {
+		switch r.Method {
+		case http.MethodGet:
+			api.list(w, r, collection)
+		case http.MethodPost:
+			body["id"] = api.nextId
+			collection[api.nextId] = body
+			api.nextId = api.nextId + 1
+			json.NewEncoder(w).Encode(body)
+		default:
+			writeMessage(w, http.StatusMethodNotAllowed, "method not allowed")
+		}
+		return
+	}
+
+	id, err := strconv.ParseInt(idPart, 10, 64)
+	object, exists := collection[id]
+	if err != nil || !exists {
+		writeMessage(w, http.StatusNotFound, "not found")
+		return
+	}
+	switch {
+	case action != "" && r.Method == http.MethodPost:
+		w.WriteHeader(http.StatusAccepted)
+	case action != "":
+		writeMessage(w, http.StatusNotFound, "not found")
+	case r.Method == http.MethodGet:
+		json.NewEncoder(w).Encode(object)
+	case r.Method == http.MethodPut:
+		body["id"] = id
+		collection[id] = body
+		json.NewEncoder(w).Encode(body)
+	case r.Method == http.MethodPatch:
+		for key, value := range body {
+			object[key] = value
+		}
+		object["id"] = id
+		json.NewEncoder(w).Encode(object)
+	case r.Method == http.MethodDelete:
+		delete(collection, id)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeMessage(w, http.StatusMethodNotAllowed, "method not allowed")
+	}
+}
+
+// list serves a page of the collection. Cursors are the offset of the
+// page.
+func (api *CrudApi) list(w http.ResponseWriter, r *http.Request, collection map[int64]map[string]interface{}) {
+	objects := api.sortedObjects(collection)
+
+	offset := 0
+	if from := r.URL.Query().Get("from"); from != "" {
+		parsed, err := strconv.Atoi(from)
+		if err != nil || parsed < 0 || parsed > len(objects) {
+			writeMessage(w, http.StatusBadRequest, "invalid cursor")
+			return
+		}
+		offset = parsed
+	}
+
+	end := len(objects)
+	if api.PageSize > 0 && offset+api.PageSize < end {
+		end = offset + api.PageSize
+	}
+	var next interface{}
+	if end < len(objects) {
+		next = strconv.Itoa(end)
+	}
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"next":  next,
+		"items": objects[offset:end],
+	})
+}
+
+func writeMessage(w http.ResponseWriter, statusCode int, message string) {
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(map[string]string{"message": message})
+}
@@ -0,0 +1,98 @@
+package flareiotest
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/Flared/go-flareio"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCrudApi(t *testing.T) {
+	api := NewCrudApi("/widgets")
+	api.PageSize = 2
+	server := NewServer(api)
+	defer server.Close()
+
+	ctx := context.Background()
+	client := server.Client()
+
+	var created map[string]interface{}
+	for _, name := range []string{"a", "b", "c"} {
+		err := client.DoJson(ctx, http.MethodPost, "/widgets", map[string]interface{}{"name": name}, &created)
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, float64(3), created["id"])
+
+	var patched map[string]interface{}
+	err := client.DoJson(ctx, http.MethodPatch, "/widgets/1", map[string]interface{}{"color": "blue"}, &patched)
+	if assert.NoError(t, err) {
+		assert.Equal(t, map[string]interface{}{"id": float64(1), "name": "a", "color": "blue"}, patched)
+	}
+	err = client.DoJson(ctx, http.MethodPut, "/widgets/2", map[string]interface{}{"name": "B"}, nil)
+	assert.NoError(t, err)
+	assert.NoError(t, client.DoJson(ctx, http.MethodPost, "/widgets/2/_test", nil, nil))
+	assert.NoError(t, client.DoJson(ctx, http.MethodDelete, "/widgets/3", nil, nil))
+
+	err = client.DoJson(ctx, http.MethodGet, "/widgets/3", nil, nil)
+	var apiErr *flareio.ApiError
+	if assert.ErrorAs(t, err, &apiErr) {
+		assert.Equal(t, http.StatusNotFound, apiErr.StatusCode)
+		assert.Equal(t, "not found", apiErr.Message)
+	}
+
+	for i := 0; i < 2; i++ {
+		assert.NoError(t, client.DoJson(ctx, http.MethodPost, "/widgets", map[string]interface{}{"name": "d"}, nil))
+	}
+	names := []string{}
+	pager := client.PagerGet("/widgets", nil)
+	for pager.HasMore() {
+		if len(names) > 10 {
+			// We are going crazy here...
+			break
+		}
+		items, err := flareio.NextDecoded(
+			ctx,
+			pager,
+			func(item json.RawMessage) (string, error) {
+				var widget struct {
+					Name string `json:"name"`
+				}
+				err := json.Unmarshal(item, &widget)
+				return widget.Name, err
+			},
+		)
+		if !assert.NoError(t, err) {
+			break
+		}
+		names = append(names, items...)
+	}
+	assert.Equal(t, []string{"a", "B", "d", "d"}, names)
+	assert.Len(t, api.Objects("/widgets"), 4)
+
+	assert.Equal(
+		t,
+		[]string{
+			"POST /widgets",
+			"POST /widgets",
+			"POST /widgets",
+			"PATCH /widgets/1",
+			"PUT /widgets/2",
+			"POST /widgets/2/_test",
+			"DELETE /widgets/3",
+			"GET /widgets/3",
+			"POST /widgets",
+			"POST /widgets",
+			"GET /widgets",
+			"GET /widgets",
+		},
+		api.Requests(),
+	)
+	api.ResetRequests()
+	assert.Empty(t, api.Requests())
+
+	err = client.DoJson(ctx, http.MethodGet, "/gadgets", nil, nil)
+	assert.ErrorAs(t, err, &apiErr)
+}
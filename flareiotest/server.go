@@ -0,0 +1,46 @@
+// Package flareiotest provides a fake Flare API server for testing
+// code that uses flareio.
+package flareiotest
+
+import (
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/Flared/go-flareio"
+)
+
+// Token is the API token generated by the fake server.
+const Token = "test-api-token"
+
+// Server is a fake Flare API server.
+//
+// It generates API tokens and passes every other request to its handler.
+type Server struct {
+	*httptest.Server
+}
+
+// NewServer can be used to start a new Server that serves the
+// requests with the given handler. It must be closed once done.
+func NewServer(handler http.Handler) *Server {
+	return &Server{
+		Server: httptest.NewServer(
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method == http.MethodPost && r.URL.Path == "/tokens/generate" {
+					w.Write([]byte(`{"token": "` + Token + `"}`))
+					return
+				}
+				handler.ServeHTTP(w, r)
+			}),
+		),
+	}
+}
+
+// Client can be used to create a new ApiClient that sends its
+// requests to the server.
+func (server *Server) Client(optionFns ...flareio.ApiClientOption) *flareio.ApiClient {
+	optionFns = append(
+		[]flareio.ApiClientOption{flareio.WithBaseUrl(server.URL)},
+		optionFns...,
+	)
+	return flareio.NewApiClient("test-api-key", optionFns...)
+}
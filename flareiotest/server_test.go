@@ -0,0 +1,30 @@
+package flareiotest
+
+import (
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServer(t *testing.T) {
+	server := NewServer(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/tokens/test", r.URL.Path)
+			assert.Equal(t, "Bearer "+Token, r.Header.Get("Authorization"))
+			w.Write([]byte(`{"hello": "world"}`))
+		}),
+	)
+	defer server.Close()
+
+	resp, err := server.Client().Get("/tokens/test", nil)
+	if !assert.NoError(t, err, "failed to make request") {
+		return
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	assert.NoError(t, err, "failed to read body")
+	assert.Equal(t, `{"hello": "world"}`, string(body))
+}
@@ -0,0 +1,21 @@
+// Package identifiers allows managing the identifiers monitored by a
+// tenant, such as its domains, emails and IP ranges.
+package identifiers
+
+import "github.com/Flared/go-flareio"
+
+// Client allows using the identifier endpoints of the Flare API.
+//
+// Its methods return an *flareio.ApiError if the API responds with an
+// unsuccessful status.
+type Client struct {
+	apiClient *flareio.ApiClient
+}
+
+// NewClient can be used to create a new Client that performs its
+// requests with the given ApiClient.
+func NewClient(apiClient *flareio.ApiClient) *Client {
+	return &Client{
+		apiClient: apiClient,
+	}
+}
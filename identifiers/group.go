@@ -0,0 +1,87 @@
+package identifiers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/Flared/go-flareio"
+)
+
+const groupsPath = "/firework/v3/identifier_groups"
+
+// Group is a named group of identifiers.
+type Group struct {
+	// Id is set by the API when the group is created.
+	Id int64 `json:"id,omitempty"`
+
+	Name string `json:"name"`
+}
+
+// Validate returns an error if the group is invalid.
+func (group *Group) Validate() error {
+	if strings.TrimSpace(group.Name) == "" {
+		return errors.New("group has no name")
+	}
+	return nil
+}
+
+func groupPath(id int64) string {
+	return fmt.Sprintf("%s/%d", groupsPath, id)
+}
+
+// CreateGroup creates the group and returns it as created by the API.
+func (client *Client) CreateGroup(ctx context.Context, group Group) (*Group, error) {
+	if err := group.Validate(); err != nil {
+		return nil, err
+	}
+	var created Group
+	if err := client.apiClient.DoJson(ctx, http.MethodPost, groupsPath, group, &created); err != nil {
+		return nil, fmt.Errorf("failed to create group: %w", err)
+	}
+	return &created, nil
+}
+
+// GetGroup fetches the group with the given id.
+func (client *Client) GetGroup(ctx context.Context, id int64) (*Group, error) {
+	var group Group
+	if err := client.apiClient.DoJson(ctx, http.MethodGet, groupPath(id), nil, &group); err != nil {
+		return nil, fmt.Errorf("failed to get group: %w", err)
+	}
+	return &group, nil
+}
+
+// ListGroups fetches all the groups of the tenant.
+func (client *Client) ListGroups(ctx context.Context) ([]*Group, error) {
+	groups, err := flareio.ListAll[Group](ctx, client.apiClient, groupsPath, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list groups: %w", err)
+	}
+	return groups, nil
+}
+
+// UpdateGroup replaces the group that has the id of the given group,
+// and returns it as updated by the API.
+func (client *Client) UpdateGroup(ctx context.Context, group Group) (*Group, error) {
+	if group.Id == 0 {
+		return nil, errors.New("group to update has no id")
+	}
+	if err := group.Validate(); err != nil {
+		return nil, err
+	}
+	var updated Group
+	if err := client.apiClient.DoJson(ctx, http.MethodPut, groupPath(group.Id), group, &updated); err != nil {
+		return nil, fmt.Errorf("failed to update group: %w", err)
+	}
+	return &updated, nil
+}
+
+// DeleteGroup deletes the group with the given id.
+func (client *Client) DeleteGroup(ctx context.Context, id int64) error {
+	if err := client.apiClient.DoJson(ctx, http.MethodDelete, groupPath(id), nil, nil); err != nil {
+		return fmt.Errorf("failed to delete group: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,45 @@
+package identifiers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGroupCrud(t *testing.T) {
+	api, server := newFakeApi()
+	defer server.Close()
+
+	ctx := context.Background()
+	client := NewClient(server.Client())
+
+	_, err := client.CreateGroup(ctx, Group{Name: " "})
+	assert.ErrorContains(t, err, "group has no name")
+
+	first, err := client.CreateGroup(ctx, Group{Name: "Domains"})
+	if !assert.NoError(t, err) {
+		return
+	}
+	second, err := client.CreateGroup(ctx, Group{Name: "Executives"})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	first.Name = "Corporate domains"
+	_, err = client.UpdateGroup(ctx, *first)
+	assert.NoError(t, err)
+
+	fetched, err := client.GetGroup(ctx, first.Id)
+	if assert.NoError(t, err) {
+		assert.Equal(t, "Corporate domains", fetched.Name)
+	}
+
+	assert.NoError(t, client.DeleteGroup(ctx, second.Id))
+
+	groups, err := client.ListGroups(ctx)
+	if assert.NoError(t, err) {
+		assert.Equal(t, []*Group{{Id: first.Id, Name: "Corporate domains"}}, groups)
+	}
+	assert.Len(t, api.Objects(groupsPath), 1)
+}
@@ -0,0 +1,223 @@
+package identifiers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/mail"
+	"strings"
+	"time"
+
+	"github.com/Flared/go-flareio"
+)
+
+const identifiersPath = "/firework/v3/identifiers"
+
+// IdentifierType is the kind of asset that an identifier monitors.
+type IdentifierType string
+
+const (
+	TypeDomain  IdentifierType = "domain"
+	TypeEmail   IdentifierType = "email"
+	TypeKeyword IdentifierType = "keyword"
+	TypeBrand   IdentifierType = "brand"
+	TypeIpRange IdentifierType = "ip_range"
+)
+
+// Identifier is an asset monitored by the tenant.
+type Identifier struct {
+	// Id is set by the API when the identifier is created.
+	Id int64 `json:"id,omitempty"`
+
+	Type IdentifierType `json:"type"`
+
+	// Value is the monitored asset, such as a domain or a CIDR.
+	Value string `json:"value"`
+
+	// Name is the display name of the identifier.
+	Name string `json:"name,omitempty"`
+
+	// GroupId is the id of the group of the identifier, if any.
+	GroupId int64 `json:"group_id,omitempty"`
+
+	IsDisabled bool       `json:"is_disabled"`
+	CreatedAt  *time.Time `json:"created_at,omitempty"`
+}
+
+// Validate returns an error if the value is invalid for the type of
+// the identifier.
+func (identifier *Identifier) Validate() error {
+	value := identifier.Value
+	switch identifier.Type {
+	case TypeDomain:
+		return validateDomain(value)
+	case TypeEmail:
+		return validateEmail(value)
+	case TypeIpRange:
+		if _, _, err := net.ParseCIDR(value); err != nil {
+			return fmt.Errorf("invalid CIDR %q", value)
+		}
+		return nil
+	case TypeKeyword, TypeBrand:
+		if strings.TrimSpace(value) == "" {
+			return fmt.Errorf("%s identifier is empty", identifier.Type)
+		}
+		return nil
+	case "":
+		return errors.New("identifier has no type")
+	default:
+		return fmt.Errorf("unknown identifier type %q", identifier.Type)
+	}
+}
+
+func validateDomain(fqdn string) error {
+	if fqdn == "" || len(fqdn) > 253 {
+		return fmt.Errorf("invalid domain %q: must have between 1 and 253 characters", fqdn)
+	}
+	labels := strings.Split(strings.TrimSuffix(fqdn, "."), ".")
+	if len(labels) < 2 {
+		return fmt.Errorf("invalid domain %q: must have at least two labels", fqdn)
+	}
+	for _, label := range labels {
+		if label == "" || len(label) > 63 {
+			return fmt.Errorf("invalid domain %q: labels must have between 1 and 63 characters", fqdn)
+		}
+		if label[0] == '-' || label[len(label)-1] == '-' {
+			return fmt.Errorf("invalid domain %q: labels cannot start or end with a hyphen", fqdn)
+		}
+		for _, c := range label {
+			isAlphanumeric := (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+			if !isAlphanumeric && c != '-' {
+				return fmt.Errorf("invalid domain %q: invalid character %q", fqdn, c)
+			}
+		}
+	}
+	return nil
+}
+
+func validateEmail(email string) error {
+	address, err := mail.ParseAddress(email)
+	if err != nil || address.Address != email {
+		return fmt.Errorf("invalid email %q", email)
+	}
+	domain := email[strings.LastIndex(email, "@")+1:]
+	if err := validateDomain(domain); err != nil {
+		return fmt.Errorf("invalid email %q: %w", email, err)
+	}
+	return nil
+}
+
+func identifierPath(id int64) string {
+	return fmt.Sprintf("%s/%d", identifiersPath, id)
+}
+
+// CreateIdentifier creates the identifier and returns it as created
+// by the API.
+func (client *Client) CreateIdentifier(
+	ctx context.Context,
+	identifier Identifier,
+) (*Identifier, error) {
+	if err := identifier.Validate(); err != nil {
+		return nil, err
+	}
+	var created Identifier
+	if err := client.apiClient.DoJson(ctx, http.MethodPost, identifiersPath, identifier, &created); err != nil {
+		return nil, fmt.Errorf("failed to create identifier: %w", err)
+	}
+	return &created, nil
+}
+
+// GetIdentifier fetches the identifier with the given id.
+func (client *Client) GetIdentifier(ctx context.Context, id int64) (*Identifier, error) {
+	var identifier Identifier
+	if err := client.apiClient.DoJson(ctx, http.MethodGet, identifierPath(id), nil, &identifier); err != nil {
+		return nil, fmt.Errorf("failed to get identifier: %w", err)
+	}
+	return &identifier, nil
+}
+
+// UpdateIdentifier replaces the identifier that has the id of the
+// given identifier, and returns it as updated by the API.
+func (client *Client) UpdateIdentifier(
+	ctx context.Context,
+	identifier Identifier,
+) (*Identifier, error) {
+	if identifier.Id == 0 {
+		return nil, errors.New("identifier to update has no id")
+	}
+	if err := identifier.Validate(); err != nil {
+		return nil, err
+	}
+	var updated Identifier
+	if err := client.apiClient.DoJson(ctx, http.MethodPut, identifierPath(identifier.Id), identifier, &updated); err != nil {
+		return nil, fmt.Errorf("failed to update identifier: %w", err)
+	}
+	return &updated, nil
+}
+
+// EnableIdentifier resumes the monitoring of the identifier.
+func (client *Client) EnableIdentifier(ctx context.Context, id int64) error {
+	return client.setIdentifierDisabled(ctx, id, false)
+}
+
+// DisableIdentifier pauses the monitoring of the identifier
+// without deleting it.
+func (client *Client) DisableIdentifier(ctx context.Context, id int64) error {
+	return client.setIdentifierDisabled(ctx, id, true)
+}
+
+func (client *Client) setIdentifierDisabled(ctx context.Context, id int64, disabled bool) error {
+	body := map[string]interface{}{
+		"is_disabled": disabled,
+	}
+	if err := client.apiClient.DoJson(ctx, http.MethodPatch, identifierPath(id), body, nil); err != nil {
+		return fmt.Errorf("failed to update identifier: %w", err)
+	}
+	return nil
+}
+
+// DeleteIdentifier deletes the identifier with the given id.
+func (client *Client) DeleteIdentifier(ctx context.Context, id int64) error {
+	if err := client.apiClient.DoJson(ctx, http.MethodDelete, identifierPath(id), nil, nil); err != nil {
+		return fmt.Errorf("failed to delete identifier: %w", err)
+	}
+	return nil
+}
+
+func decodeIdentifier(item json.RawMessage) (*Identifier, error) {
+	var identifier Identifier
+	if err := json.Unmarshal(item, &identifier); err != nil {
+		return nil, fmt.Errorf("failed to decode identifier: %w", err)
+	}
+	return &identifier, nil
+}
+
+// IdentifiersPager allows fetching the identifiers of the tenant one
+// page at a time.
+type IdentifiersPager struct {
+	pager *flareio.Pager
+}
+
+// PagerIdentifiers returns a pager over the identifiers of the tenant.
+func (client *Client) PagerIdentifiers(optionFns ...flareio.IterOption) *IdentifiersPager {
+	return &IdentifiersPager{
+		pager: client.apiClient.PagerGet(identifiersPath, nil, optionFns...),
+	}
+}
+
+// HasMore returns whether there are pages left to fetch.
+func (pager *IdentifiersPager) HasMore() bool {
+	return pager.pager.HasMore()
+}
+
+// Next fetches the identifiers of the next page.
+//
+// It behaves like flareio.Pager.Next. Items that cannot be decoded
+// are reported with an *flareio.ItemErrors along with the others,
+// and the pager continues.
+func (pager *IdentifiersPager) Next(ctx context.Context) ([]*Identifier, error) {
+	return flareio.NextDecoded(ctx, pager.pager, decodeIdentifier)
+}
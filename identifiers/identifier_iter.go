@@ -0,0 +1,26 @@
+//go:build go1.23
+
+package identifiers
+
+import (
+	"iter"
+
+	"github.com/Flared/go-flareio"
+)
+
+// IterIdentifiers allows to iterate over the identifiers of the tenant.
+//
+// An identifier that cannot be decoded is yielded as an error, and the
+// iteration continues if the consumer doesn't stop.
+func (client *Client) IterIdentifiers(
+	optionFns ...flareio.IterOption,
+) iter.Seq2[*Identifier, error] {
+	return flareio.IterDecoded(
+		client.apiClient.IterGetItems(
+			identifiersPath,
+			nil,
+			optionFns...,
+		),
+		decodeIdentifier,
+	)
+}
@@ -0,0 +1,39 @@
+//go:build go1.23
+
+package identifiers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIterIdentifiers(t *testing.T) {
+	_, server := newFakeApi()
+	defer server.Close()
+
+	client := NewClient(server.Client())
+	for _, value := range []string{"10.0.0.0/8", "192.168.0.0/16"} {
+		_, err := client.CreateIdentifier(
+			context.Background(),
+			Identifier{Type: TypeIpRange, Value: value},
+		)
+		if !assert.NoError(t, err) {
+			return
+		}
+	}
+
+	values := []string{}
+	for identifier, err := range client.IterIdentifiers() {
+		if len(values) > 10 {
+			// We are going crazy here...
+			break
+		}
+		if !assert.NoError(t, err, "iter yielded an error") {
+			break
+		}
+		values = append(values, identifier.Value)
+	}
+	assert.ElementsMatch(t, []string{"10.0.0.0/8", "192.168.0.0/16"}, values)
+}
@@ -0,0 +1,175 @@
+package identifiers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/Flared/go-flareio"
+	"github.com/Flared/go-flareio/flareiotest"
+	"github.com/stretchr/testify/assert"
+)
+
+// newFakeApi serves identifiers and groups from memory, listing them
+// on pages of two.
+func newFakeApi() (*flareiotest.CrudApi, *flareiotest.Server) {
+	api := flareiotest.NewCrudApi(identifiersPath, groupsPath)
+	api.PageSize = 2
+	return api, flareiotest.NewServer(api)
+}
+
+func TestIdentifierValidate(t *testing.T) {
+	for _, identifier := range []Identifier{
+		{Type: TypeDomain, Value: "example.com"},
+		{Type: TypeDomain, Value: "sub-domain.example.co.uk."},
+		{Type: TypeEmail, Value: "john.doe@example.com"},
+		{Type: TypeIpRange, Value: "10.0.0.0/8"},
+		{Type: TypeIpRange, Value: "2001:db8::/32"},
+		{Type: TypeKeyword, Value: "acme"},
+		{Type: TypeBrand, Value: "Acme Corp"},
+	} {
+		assert.NoError(t, identifier.Validate(), "%s should be valid", identifier.Value)
+	}
+
+	for _, tc := range []struct {
+		identifier Identifier
+		expected   string
+	}{
+		{Identifier{Type: TypeDomain, Value: "localhost"}, "must have at least two labels"},
+		{Identifier{Type: TypeDomain, Value: "-example.com"}, "cannot start or end with a hyphen"},
+		{Identifier{Type: TypeDomain, Value: "exa_mple.com"}, "invalid character '_'"},
+		{Identifier{Type: TypeDomain, Value: "example..com"}, "between 1 and 63 characters"},
+		{Identifier{Type: TypeDomain, Value: ""}, "between 1 and 253 characters"},
+		{Identifier{Type: TypeEmail, Value: "John <john@example.com>"}, "invalid email"},
+		{Identifier{Type: TypeEmail, Value: "john@localhost"}, "must have at least two labels"},
+		{Identifier{Type: TypeIpRange, Value: "10.0.0.1"}, "invalid CIDR"},
+		{Identifier{Type: TypeKeyword, Value: " "}, "keyword identifier is empty"},
+		{Identifier{Value: "example.com"}, "identifier has no type"},
+		{Identifier{Type: "phone", Value: "555-1234"}, `unknown identifier type "phone"`},
+	} {
+		assert.ErrorContains(t, tc.identifier.Validate(), tc.expected)
+	}
+}
+
+func TestIdentifierCrud(t *testing.T) {
+	api, server := newFakeApi()
+	defer server.Close()
+
+	ctx := context.Background()
+	client := NewClient(server.Client())
+
+	created, err := client.CreateIdentifier(ctx, Identifier{
+		Type:  TypeDomain,
+		Value: "example.com",
+	})
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, int64(1), created.Id)
+
+	created.Name = "Example"
+	updated, err := client.UpdateIdentifier(ctx, *created)
+	if assert.NoError(t, err) {
+		assert.Equal(t, "Example", updated.Name)
+	}
+
+	assert.NoError(t, client.DisableIdentifier(ctx, created.Id))
+	fetched, err := client.GetIdentifier(ctx, created.Id)
+	if assert.NoError(t, err) {
+		assert.True(t, fetched.IsDisabled)
+	}
+	assert.NoError(t, client.EnableIdentifier(ctx, created.Id))
+	fetched, err = client.GetIdentifier(ctx, created.Id)
+	if assert.NoError(t, err) {
+		assert.False(t, fetched.IsDisabled)
+	}
+
+	assert.NoError(t, client.DeleteIdentifier(ctx, created.Id))
+	_, err = client.GetIdentifier(ctx, created.Id)
+	var apiErr *flareio.ApiError
+	if assert.ErrorAs(t, err, &apiErr) {
+		assert.Equal(t, http.StatusNotFound, apiErr.StatusCode)
+	}
+
+	assert.Equal(
+		t,
+		[]string{
+			"POST /firework/v3/identifiers",
+			"PUT /firework/v3/identifiers/1",
+			"PATCH /firework/v3/identifiers/1",
+			"GET /firework/v3/identifiers/1",
+			"PATCH /firework/v3/identifiers/1",
+			"GET /firework/v3/identifiers/1",
+			"DELETE /firework/v3/identifiers/1",
+			"GET /firework/v3/identifiers/1",
+		},
+		api.Requests(),
+	)
+}
+
+func TestIdentifierCrudInvalid(t *testing.T) {
+	api, server := newFakeApi()
+	defer server.Close()
+
+	ctx := context.Background()
+	client := NewClient(server.Client())
+
+	_, err := client.CreateIdentifier(ctx, Identifier{Type: TypeEmail, Value: "john"})
+	assert.ErrorContains(t, err, "invalid email")
+
+	_, err = client.UpdateIdentifier(ctx, Identifier{Type: TypeKeyword, Value: "acme"})
+	assert.ErrorContains(t, err, "identifier to update has no id")
+
+	assert.Empty(t, api.Requests(), "invalid identifiers should not be sent")
+}
+
+func TestPagerIdentifiers(t *testing.T) {
+	_, server := newFakeApi()
+	defer server.Close()
+
+	ctx := context.Background()
+	client := NewClient(server.Client())
+	for _, value := range []string{"a.com", "b.com", "c.com"} {
+		_, err := client.CreateIdentifier(ctx, Identifier{Type: TypeDomain, Value: value})
+		if !assert.NoError(t, err) {
+			return
+		}
+	}
+
+	pager := client.PagerIdentifiers()
+	values := []string{}
+	for pager.HasMore() {
+		if len(values) > 10 {
+			// We are going crazy here...
+			break
+		}
+		page, err := pager.Next(ctx)
+		if !assert.NoError(t, err, "pager returned an error") {
+			return
+		}
+		for _, identifier := range page {
+			values = append(values, identifier.Value)
+		}
+	}
+	assert.ElementsMatch(t, []string{"a.com", "b.com", "c.com"}, values)
+}
+
+func TestClientSendError(t *testing.T) {
+	server := flareiotest.NewServer(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"message": "identifier already exists"}`))
+		}),
+	)
+	defer server.Close()
+
+	_, err := NewClient(server.Client()).CreateIdentifier(
+		context.Background(),
+		Identifier{Type: TypeDomain, Value: "example.com"},
+	)
+	assert.EqualError(t, err, "failed to create identifier: got http status code 400: identifier already exists")
+
+	var apiErr *flareio.ApiError
+	assert.True(t, errors.As(err, &apiErr))
+}
@@ -0,0 +1,330 @@
+package identifiers
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ErrTooManyDeletions is returned when applying a plan that deletes
+// more identifiers than allowed.
+var ErrTooManyDeletions = errors.New("plan deletes too many identifiers")
+
+// DesiredIdentifier is an identifier that should be monitored.
+type DesiredIdentifier struct {
+	Type  IdentifierType `yaml:"type"`
+	Value string         `yaml:"value"`
+
+	// Name is the display name of the identifier. The current name is
+	// kept when it is empty.
+	Name string `yaml:"name,omitempty"`
+
+	// Disabled is whether the monitoring of the identifier is paused.
+	Disabled bool `yaml:"disabled,omitempty"`
+}
+
+func (desired *DesiredIdentifier) key() string {
+	return identifierKey(desired.Type, desired.Value)
+}
+
+// DesiredState lists all the identifiers that a tenant should monitor.
+//
+// It is usually loaded from a YAML or JSON file such as:
+//
+//	identifiers:
+//	  - type: domain
+//	    value: example.com
+//	  - type: keyword
+//	    value: acme
+//	    name: Acme brand
+//	    disabled: true
+type DesiredState struct {
+	Identifiers []DesiredIdentifier `yaml:"identifiers"`
+}
+
+// ParseDesiredState decodes and validates a desired state in the YAML
+// or JSON format. Unknown fields are rejected.
+func ParseDesiredState(data []byte) (*DesiredState, error) {
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+
+	var state DesiredState
+	if err := decoder.Decode(&state); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("failed to decode desired state: %w", err)
+	}
+	if err := state.Validate(); err != nil {
+		return nil, err
+	}
+	return &state, nil
+}
+
+// LoadDesiredState reads the desired state in the file at the given path.
+func LoadDesiredState(path string) (*DesiredState, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read desired state: %w", err)
+	}
+	return ParseDesiredState(data)
+}
+
+// Validate returns an error if an identifier is invalid or listed
+// more than once.
+func (state *DesiredState) Validate() error {
+	seen := map[string]struct{}{}
+	for _, desired := range state.Identifiers {
+		identifier := Identifier{
+			Type:  desired.Type,
+			Value: desired.Value,
+		}
+		if err := identifier.Validate(); err != nil {
+			return fmt.Errorf("invalid desired identifier: %w", err)
+		}
+		key := desired.key()
+		if _, duplicate := seen[key]; duplicate {
+			return fmt.Errorf("duplicate desired identifier: %s %s", desired.Type, desired.Value)
+		}
+		seen[key] = struct{}{}
+	}
+	return nil
+}
+
+// identifierKey identifies an identifier regardless of the case of
+// domains and emails.
+func identifierKey(identifierType IdentifierType, value string) string {
+	switch identifierType {
+	case TypeDomain, TypeEmail:
+		value = strings.ToLower(strings.TrimSuffix(value, "."))
+	}
+	return string(identifierType) + ":" + value
+}
+
+// ChangeAction is what a change does to an identifier.
+type ChangeAction string
+
+const (
+	ActionCreate ChangeAction = "create"
+	ActionUpdate ChangeAction = "update"
+	ActionDelete ChangeAction = "delete"
+)
+
+// Change is a change to an identifier of a plan.
+type Change struct {
+	Action ChangeAction
+
+	// Current is the identifier as it exists. It is nil when creating.
+	Current *Identifier
+
+	// Desired is the identifier as it should exist. It is nil when
+	// deleting.
+	Desired *Identifier
+}
+
+func (change Change) String() string {
+	switch change.Action {
+	case ActionCreate:
+		return fmt.Sprintf("+ create %s %s%s", change.Desired.Type, change.Desired.Value, describeIdentifier(change.Desired))
+	case ActionDelete:
+		return fmt.Sprintf("- delete %s %s", change.Current.Type, change.Current.Value)
+	default:
+		details := []string{}
+		if change.Current.Name != change.Desired.Name {
+			details = append(details, fmt.Sprintf("name: %q -> %q", change.Current.Name, change.Desired.Name))
+		}
+		if change.Current.IsDisabled != change.Desired.IsDisabled {
+			details = append(details, fmt.Sprintf("disabled: %t -> %t", change.Current.IsDisabled, change.Desired.IsDisabled))
+		}
+		return fmt.Sprintf(
+			"~ update %s %s (%s)",
+			change.Current.Type,
+			change.Current.Value,
+			strings.Join(details, ", "),
+		)
+	}
+}
+
+func describeIdentifier(identifier *Identifier) string {
+	details := []string{}
+	if identifier.Name != "" {
+		details = append(details, fmt.Sprintf("name: %q", identifier.Name))
+	}
+	if identifier.IsDisabled {
+		details = append(details, "disabled")
+	}
+	if len(details) == 0 {
+		return ""
+	}
+	return " (" + strings.Join(details, ", ") + ")"
+}
+
+// Plan is the list of changes that make the current identifiers of a
+// tenant match its desired state.
+type Plan struct {
+	// Changes are ordered by action: creates, then updates, then deletes.
+	Changes []Change
+}
+
+// Count returns the number of changes with the given action.
+func (plan *Plan) Count(action ChangeAction) int {
+	count := 0
+	for _, change := range plan.Changes {
+		if change.Action == action {
+			count = count + 1
+		}
+	}
+	return count
+}
+
+// String describes the plan in a human-readable form, one change
+// per line followed by a summary.
+func (plan *Plan) String() string {
+	if len(plan.Changes) == 0 {
+		return "No changes, the identifiers match the desired state.\n"
+	}
+	var builder strings.Builder
+	for _, change := range plan.Changes {
+		builder.WriteString(change.String())
+		builder.WriteString("\n")
+	}
+	fmt.Fprintf(
+		&builder,
+		"\nPlan: %d to create, %d to update, %d to delete.\n",
+		plan.Count(ActionCreate),
+		plan.Count(ActionUpdate),
+		plan.Count(ActionDelete),
+	)
+	return builder.String()
+}
+
+// PlanSync compares the current identifiers of the tenant with the
+// desired state and returns the changes needed to match it.
+//
+// Current identifiers that are not in the desired state are deleted.
+// So are the duplicates of those that are, such as identifiers whose
+// values only differ by case: only the first of them is kept.
+func (client *Client) PlanSync(ctx context.Context, desired *DesiredState) (*Plan, error) {
+	if err := desired.Validate(); err != nil {
+		return nil, err
+	}
+
+	current := map[string][]*Identifier{}
+	currentOrder := []*Identifier{}
+	pager := client.PagerIdentifiers()
+	for pager.HasMore() {
+		identifiers, err := pager.Next(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list identifiers: %w", err)
+		}
+		for _, identifier := range identifiers {
+			key := identifierKey(identifier.Type, identifier.Value)
+			current[key] = append(current[key], identifier)
+			currentOrder = append(currentOrder, identifier)
+		}
+	}
+
+	creates := []Change{}
+	updates := []Change{}
+	wanted := map[string]struct{}{}
+	for _, desiredIdentifier := range desired.Identifiers {
+		key := desiredIdentifier.key()
+		wanted[key] = struct{}{}
+
+		matches := current[key]
+		if len(matches) == 0 {
+			creates = append(creates, Change{
+				Action: ActionCreate,
+				Desired: &Identifier{
+					Type:       desiredIdentifier.Type,
+					Value:      desiredIdentifier.Value,
+					Name:       desiredIdentifier.Name,
+					IsDisabled: desiredIdentifier.Disabled,
+				},
+			})
+			continue
+		}
+
+		existing := matches[0]
+		updated := *existing
+		if desiredIdentifier.Name != "" {
+			updated.Name = desiredIdentifier.Name
+		}
+		updated.IsDisabled = desiredIdentifier.Disabled
+		if updated.Name != existing.Name || updated.IsDisabled != existing.IsDisabled {
+			updates = append(updates, Change{
+				Action:  ActionUpdate,
+				Current: existing,
+				Desired: &updated,
+			})
+		}
+	}
+
+	deletes := []Change{}
+	for _, identifier := range currentOrder {
+		key := identifierKey(identifier.Type, identifier.Value)
+		_, isWanted := wanted[key]
+		if !isWanted || current[key][0] != identifier {
+			deletes = append(deletes, Change{
+				Action:  ActionDelete,
+				Current: identifier,
+			})
+		}
+	}
+
+	changes := append(creates, updates...)
+	return &Plan{
+		Changes: append(changes, deletes...),
+	}, nil
+}
+
+// ApplyOptions configures how a plan is applied.
+type ApplyOptions struct {
+	// DryRun checks the safeguards without changing anything.
+	DryRun bool
+
+	// MaxDeletions is the maximum number of identifiers that the plan
+	// may delete. Zero doesn't allow any deletion and a negative value
+	// allows any number of deletions.
+	MaxDeletions int
+}
+
+// ApplyPlan performs the changes of the plan in order.
+//
+// It stops at the first change that fails, in which case the changes
+// before it were applied.
+func (client *Client) ApplyPlan(ctx context.Context, plan *Plan, options ApplyOptions) error {
+	deletions := plan.Count(ActionDelete)
+	if options.MaxDeletions >= 0 && deletions > options.MaxDeletions {
+		return fmt.Errorf(
+			"%w: %d deletions while at most %d are allowed",
+			ErrTooManyDeletions,
+			deletions,
+			options.MaxDeletions,
+		)
+	}
+	if options.DryRun {
+		return nil
+	}
+
+	for _, change := range plan.Changes {
+		var err error
+		switch change.Action {
+		case ActionCreate:
+			_, err = client.CreateIdentifier(ctx, *change.Desired)
+		case ActionUpdate:
+			_, err = client.UpdateIdentifier(ctx, *change.Desired)
+		case ActionDelete:
+			err = client.DeleteIdentifier(ctx, change.Current.Id)
+		default:
+			err = fmt.Errorf("unknown action %q", change.Action)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to apply change %q: %w", change, err)
+		}
+	}
+	return nil
+}
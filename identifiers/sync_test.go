@@ -0,0 +1,234 @@
+package identifiers
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Flared/go-flareio/flareiotest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseDesiredState(t *testing.T) {
+	state, err := ParseDesiredState([]byte(`
+identifiers:
+  - type: domain
+    value: example.com
+  - type: keyword
+    value: acme
+    name: Acme brand
+    disabled: true
+`))
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, &DesiredState{
+		Identifiers: []DesiredIdentifier{
+			{Type: TypeDomain, Value: "example.com"},
+			{Type: TypeKeyword, Value: "acme", Name: "Acme brand", Disabled: true},
+		},
+	}, state)
+
+	jsonState, err := ParseDesiredState([]byte(`{
+		"identifiers": [{"type": "domain", "value": "example.com"}]
+	}`))
+	if assert.NoError(t, err) {
+		assert.Equal(t, state.Identifiers[:1], jsonState.Identifiers)
+	}
+
+	emptyState, err := ParseDesiredState([]byte(""))
+	if assert.NoError(t, err) {
+		assert.Empty(t, emptyState.Identifiers)
+	}
+}
+
+func TestParseDesiredStateInvalid(t *testing.T) {
+	for _, tc := range []struct {
+		data     string
+		expected string
+	}{
+		{"identifiers:\n  - type: domain\n    valeu: example.com\n", "field valeu not found"},
+		{"identifiers:\n  - type: domain\n    value: not a domain\n", "invalid desired identifier"},
+		{
+			"identifiers:\n  - type: domain\n    value: example.com\n  - type: domain\n    value: EXAMPLE.com\n",
+			"duplicate desired identifier: domain EXAMPLE.com",
+		},
+	} {
+		_, err := ParseDesiredState([]byte(tc.data))
+		assert.ErrorContains(t, err, tc.expected)
+	}
+}
+
+func TestLoadDesiredState(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "identifiers.yaml")
+	err := os.WriteFile(path, []byte("identifiers:\n  - type: email\n    value: ceo@example.com\n"), 0o600)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	state, err := LoadDesiredState(path)
+	if assert.NoError(t, err) {
+		assert.Equal(t, "ceo@example.com", state.Identifiers[0].Value)
+	}
+
+	_, err = LoadDesiredState(filepath.Join(t.TempDir(), "missing.yaml"))
+	assert.ErrorContains(t, err, "failed to read desired state")
+}
+
+// newSyncTest creates the current identifiers of the tenant.
+func newSyncTest(t *testing.T) (*flareiotest.CrudApi, *Client, func()) {
+	api, server := newFakeApi()
+	client := NewClient(server.Client())
+	for _, identifier := range []Identifier{
+		{Type: TypeDomain, Value: "Example.com", Name: "Example"},
+		{Type: TypeKeyword, Value: "acme"},
+		{Type: TypeEmail, Value: "old@example.com"},
+		{Type: TypeBrand, Value: "Acme"},
+	} {
+		_, err := client.CreateIdentifier(context.Background(), identifier)
+		assert.NoError(t, err)
+	}
+	api.ResetRequests()
+	return api, client, server.Close
+}
+
+var syncTestDesiredState = &DesiredState{
+	Identifiers: []DesiredIdentifier{
+		{Type: TypeDomain, Value: "example.com"},
+		{Type: TypeKeyword, Value: "acme", Name: "Acme keyword", Disabled: true},
+		{Type: TypeIpRange, Value: "10.0.0.0/8"},
+		{Type: TypeBrand, Value: "Acme"},
+	},
+}
+
+func TestPlanSync(t *testing.T) {
+	_, client, closeServer := newSyncTest(t)
+	defer closeServer()
+
+	plan, err := client.PlanSync(context.Background(), syncTestDesiredState)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Equal(t, 1, plan.Count(ActionCreate))
+	assert.Equal(t, 1, plan.Count(ActionUpdate))
+	assert.Equal(t, 1, plan.Count(ActionDelete))
+	assert.Equal(
+		t,
+		`+ create ip_range 10.0.0.0/8
+~ update keyword acme (name: "" -> "Acme keyword", disabled: false -> true)
+- delete email old@example.com
+
+Plan: 1 to create, 1 to update, 1 to delete.
+`,
+		plan.String(),
+	)
+}
+
+func TestPlanSyncDuplicates(t *testing.T) {
+	_, client, closeServer := newSyncTest(t)
+	defer closeServer()
+
+	ctx := context.Background()
+	duplicate, err := client.CreateIdentifier(ctx, Identifier{Type: TypeDomain, Value: "example.com"})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	plan, err := client.PlanSync(ctx, syncTestDesiredState)
+	if !assert.NoError(t, err) {
+		return
+	}
+	deletes := []*Identifier{}
+	for _, change := range plan.Changes {
+		if change.Action == ActionDelete {
+			deletes = append(deletes, change.Current)
+		}
+	}
+	if assert.Len(t, deletes, 2) {
+		assert.Equal(t, "old@example.com", deletes[0].Value)
+		assert.Equal(t, duplicate.Id, deletes[1].Id, "only the first duplicate should be kept")
+	}
+
+	plan, err = client.PlanSync(ctx, &DesiredState{})
+	if !assert.NoError(t, err) {
+		return
+	}
+	deletedIds := map[int64]struct{}{}
+	for _, change := range plan.Changes {
+		deletedIds[change.Current.Id] = struct{}{}
+	}
+	assert.Len(t, deletedIds, 5, "each identifier should be deleted once")
+}
+
+func TestPlanSyncNoChanges(t *testing.T) {
+	_, client, closeServer := newSyncTest(t)
+	defer closeServer()
+
+	plan, err := client.PlanSync(context.Background(), &DesiredState{
+		Identifiers: []DesiredIdentifier{
+			{Type: TypeDomain, Value: "example.com."},
+			{Type: TypeKeyword, Value: "acme"},
+			{Type: TypeEmail, Value: "old@example.com"},
+			{Type: TypeBrand, Value: "Acme"},
+		},
+	})
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Empty(t, plan.Changes)
+	assert.Equal(t, "No changes, the identifiers match the desired state.\n", plan.String())
+}
+
+func TestApplyPlan(t *testing.T) {
+	api, client, closeServer := newSyncTest(t)
+	defer closeServer()
+
+	ctx := context.Background()
+	plan, err := client.PlanSync(ctx, syncTestDesiredState)
+	if !assert.NoError(t, err) {
+		return
+	}
+	api.ResetRequests()
+
+	assert.NoError(t, client.ApplyPlan(ctx, plan, ApplyOptions{MaxDeletions: 1}))
+	assert.Equal(
+		t,
+		[]string{
+			"POST /firework/v3/identifiers",
+			"PUT /firework/v3/identifiers/2",
+			"DELETE /firework/v3/identifiers/3",
+		},
+		api.Requests(),
+	)
+
+	plan, err = client.PlanSync(ctx, syncTestDesiredState)
+	if assert.NoError(t, err) {
+		assert.Empty(t, plan.Changes, "identifiers should match the desired state")
+	}
+}
+
+func TestApplyPlanSafeguards(t *testing.T) {
+	api, client, closeServer := newSyncTest(t)
+	defer closeServer()
+
+	ctx := context.Background()
+	plan, err := client.PlanSync(ctx, &DesiredState{})
+	if !assert.NoError(t, err) {
+		return
+	}
+	api.ResetRequests()
+
+	err = client.ApplyPlan(ctx, plan, ApplyOptions{MaxDeletions: 2})
+	assert.ErrorIs(t, err, ErrTooManyDeletions)
+	assert.ErrorContains(t, err, "4 deletions while at most 2 are allowed")
+
+	err = client.ApplyPlan(ctx, plan, ApplyOptions{})
+	assert.ErrorIs(t, err, ErrTooManyDeletions)
+
+	err = client.ApplyPlan(ctx, plan, ApplyOptions{DryRun: true, MaxDeletions: -1})
+	assert.NoError(t, err)
+	assert.Empty(t, api.Requests(), "nothing should be changed")
+	assert.Len(t, api.Objects(identifiersPath), 4)
+}
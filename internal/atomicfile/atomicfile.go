@@ -0,0 +1,40 @@
+// Package atomicfile replaces files so that they are never left
+// partially written.
+package atomicfile
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// WriteFile atomically replaces the content of the file at the given
+// path with data.
+func WriteFile(path string, data []byte) error {
+	// Write to a temporary file in the same directory and rename it
+	// over the file so that it is never left partially written.
+	tmpFile, err := os.CreateTemp(
+		filepath.Dir(path),
+		filepath.Base(path)+".tmp-*",
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create temporary file: %w", err)
+	}
+	defer os.Remove(tmpFile.Name())
+
+	if _, err := tmpFile.Write(data); err != nil {
+		tmpFile.Close()
+		return fmt.Errorf("failed to write temporary file: %w", err)
+	}
+	if err := tmpFile.Sync(); err != nil {
+		tmpFile.Close()
+		return fmt.Errorf("failed to sync temporary file: %w", err)
+	}
+	if err := tmpFile.Close(); err != nil {
+		return fmt.Errorf("failed to close temporary file: %w", err)
+	}
+	if err := os.Rename(tmpFile.Name(), path); err != nil {
+		return fmt.Errorf("failed to replace file: %w", err)
+	}
+	return nil
+}
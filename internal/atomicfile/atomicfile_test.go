@@ -0,0 +1,34 @@
+package atomicfile
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWriteFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "file")
+
+	if !assert.NoError(t, WriteFile(path, []byte("first"))) {
+		return
+	}
+	if !assert.NoError(t, WriteFile(path, []byte("second"))) {
+		return
+	}
+
+	data, err := os.ReadFile(path)
+	if assert.NoError(t, err) {
+		assert.Equal(t, "second", string(data))
+	}
+
+	entries, err := os.ReadDir(dir)
+	if assert.NoError(t, err) {
+		assert.Len(t, entries, 1, "temporary files should be cleaned up")
+	}
+
+	err = WriteFile(filepath.Join(dir, "missing", "file"), []byte("data"))
+	assert.ErrorContains(t, err, "failed to create temporary file")
+}
@@ -0,0 +1,20 @@
+// Package leaksdb provides typed access to the LeaksDB endpoints of the
+// Flare API.
+package leaksdb
+
+import (
+	"github.com/Flared/go-flareio"
+)
+
+// Client allows using the LeaksDB endpoints of the Flare API.
+type Client struct {
+	apiClient *flareio.ApiClient
+}
+
+// NewClient can be used to create a new Client that performs its
+// requests with the given ApiClient.
+func NewClient(apiClient *flareio.ApiClient) *Client {
+	return &Client{
+		apiClient: apiClient,
+	}
+}
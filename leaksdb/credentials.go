@@ -0,0 +1,174 @@
+package leaksdb
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Flared/go-flareio"
+)
+
+const credentialsSearchPath = "/leaksdb/v2/credentials/_search"
+
+// HashType is the format of the secret of a credential.
+type HashType string
+
+const (
+	HashTypePlaintext HashType = "plaintext"
+	HashTypeMd5       HashType = "md5"
+	HashTypeSha1      HashType = "sha1"
+	HashTypeSha256    HashType = "sha256"
+	HashTypeSha512    HashType = "sha512"
+	HashTypeBcrypt    HashType = "bcrypt"
+	HashTypeNtlm      HashType = "ntlm"
+	HashTypeUnknown   HashType = "unknown"
+)
+
+// Credential is a leaked identity and its secret.
+type Credential struct {
+	Id           int64    `json:"id"`
+	IdentityName string   `json:"identity_name"`
+	Domain       string   `json:"domain"`
+	Hash         string   `json:"hash"`
+	HashType     HashType `json:"hash_type"`
+	Source       Source   `json:"source"`
+
+	// ImportedAt is when the credential was added to LeaksDB.
+	ImportedAt time.Time `json:"imported_at"`
+}
+
+// CredentialsQuery selects the credentials returned by a search.
+//
+// It is created with one of the query functions, such as DomainQuery.
+type CredentialsQuery struct {
+	queryType string
+	field     string
+	value     string
+}
+
+// DomainQuery searches the credentials of identities of the given domain.
+func DomainQuery(fqdn string) CredentialsQuery {
+	return CredentialsQuery{
+		queryType: "domain",
+		field:     "fqdn",
+		value:     fqdn,
+	}
+}
+
+// EmailQuery searches the credentials of the given email address.
+func EmailQuery(email string) CredentialsQuery {
+	return CredentialsQuery{
+		queryType: "email",
+		field:     "email",
+		value:     email,
+	}
+}
+
+// UsernameQuery searches the credentials of the given username.
+func UsernameQuery(username string) CredentialsQuery {
+	return CredentialsQuery{
+		queryType: "username",
+		field:     "username",
+		value:     username,
+	}
+}
+
+// KeywordQuery searches the credentials of identities that contain
+// the given keyword.
+func KeywordQuery(keyword string) CredentialsQuery {
+	return CredentialsQuery{
+		queryType: "keyword",
+		field:     "keyword",
+		value:     keyword,
+	}
+}
+
+// PasswordHashQuery searches the credentials with the given secret hash.
+func PasswordHashQuery(hash string) CredentialsQuery {
+	return CredentialsQuery{
+		queryType: "password_hash",
+		field:     "hash",
+		value:     hash,
+	}
+}
+
+func (query CredentialsQuery) validate() error {
+	if query.queryType == "" {
+		return errors.New("credentials query must be created with a query function")
+	}
+	if strings.TrimSpace(query.value) == "" {
+		return fmt.Errorf("%s query has an empty %s", query.queryType, query.field)
+	}
+	if query.queryType == "email" && !strings.Contains(query.value, "@") {
+		return fmt.Errorf("invalid email address: %q", query.value)
+	}
+	return nil
+}
+
+// MarshalJSON encodes the query as expected by the search endpoint.
+func (query CredentialsQuery) MarshalJSON() ([]byte, error) {
+	return json.Marshal(map[string]string{
+		"type":      query.queryType,
+		query.field: query.value,
+	})
+}
+
+func (query CredentialsQuery) searchBody() (map[string]interface{}, error) {
+	if err := query.validate(); err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{
+		"query": query,
+	}, nil
+}
+
+func decodeCredential(item json.RawMessage) (*Credential, error) {
+	var credential Credential
+	if err := json.Unmarshal(item, &credential); err != nil {
+		return nil, fmt.Errorf("failed to decode credential: %w", err)
+	}
+	return &credential, nil
+}
+
+// CredentialsPager allows fetching the credentials of a search
+// one page at a time.
+type CredentialsPager struct {
+	pager *flareio.Pager
+}
+
+// PagerCredentials returns a pager over the credentials that match
+// the query.
+func (client *Client) PagerCredentials(
+	query CredentialsQuery,
+	optionFns ...flareio.IterOption,
+) (*CredentialsPager, error) {
+	body, err := query.searchBody()
+	if err != nil {
+		return nil, err
+	}
+	return &CredentialsPager{
+		pager: client.apiClient.PagerPostJson(
+			credentialsSearchPath,
+			nil,
+			body,
+			optionFns...,
+		),
+	}, nil
+}
+
+// HasMore returns whether there are pages left to fetch.
+func (pager *CredentialsPager) HasMore() bool {
+	return pager.pager.HasMore()
+}
+
+// Next fetches the credentials of the next page.
+//
+// It behaves like flareio.Pager.Next. Items that cannot be decoded
+// are reported with an *flareio.ItemErrors along with the others,
+// and the pager continues.
+func (pager *CredentialsPager) Next(ctx context.Context) ([]*Credential, error) {
+	return flareio.NextDecoded(ctx, pager.pager, decodeCredential)
+}
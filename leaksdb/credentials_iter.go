@@ -0,0 +1,36 @@
+//go:build go1.23
+
+package leaksdb
+
+import (
+	"iter"
+
+	"github.com/Flared/go-flareio"
+)
+
+// IterCredentials allows to iterate over the credentials that match
+// the query.
+//
+// A credential that cannot be decoded is yielded as an error, and the
+// iteration continues if the consumer doesn't stop.
+func (client *Client) IterCredentials(
+	query CredentialsQuery,
+	optionFns ...flareio.IterOption,
+) iter.Seq2[*Credential, error] {
+	return func(yield func(*Credential, error) bool) {
+		body, err := query.searchBody()
+		if err != nil {
+			yield(nil, err)
+			return
+		}
+		flareio.IterDecoded(
+			client.apiClient.IterPostJsonItems(
+				credentialsSearchPath,
+				nil,
+				body,
+				optionFns...,
+			),
+			decodeCredential,
+		)(yield)
+	}
+}
@@ -0,0 +1,64 @@
+//go:build go1.23
+
+package leaksdb
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/Flared/go-flareio/flareiotest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIterCredentials(t *testing.T) {
+	server := newCredentialsTest(t)
+	defer server.Close()
+
+	identities := []string{}
+	for credential, err := range NewClient(server.Client()).IterCredentials(
+		DomainQuery("example.com"),
+	) {
+		if len(identities) > 5 {
+			// We are going crazy here...
+			break
+		}
+		if !assert.NoError(t, err, "iter yielded an error") {
+			break
+		}
+		identities = append(identities, credential.IdentityName)
+	}
+
+	assert.Equal(t, []string{"john@example.com", "jane@example.com"}, identities)
+}
+
+func TestIterCredentialsInvalidItem(t *testing.T) {
+	server := flareiotest.NewServer(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(`{"next": null, "items": [{"id": "not-a-number"}, {"id": 2}]}`))
+		}),
+	)
+	defer server.Close()
+
+	ids := []int64{}
+	errs := []error{}
+	for credential, err := range NewClient(server.Client()).IterCredentials(
+		KeywordQuery("acme"),
+	) {
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		ids = append(ids, credential.Id)
+	}
+
+	assert.Equal(t, []int64{2}, ids)
+	if assert.Len(t, errs, 1) {
+		assert.ErrorContains(t, errs[0], "failed to decode credential")
+	}
+}
+
+func TestIterCredentialsInvalidQuery(t *testing.T) {
+	for _, err := range NewClient(nil).IterCredentials(DomainQuery("")) {
+		assert.ErrorContains(t, err, "domain query has an empty fqdn")
+	}
+}
@@ -0,0 +1,175 @@
+package leaksdb
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/Flared/go-flareio"
+	"github.com/Flared/go-flareio/flareiotest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCredentialsQueryJson(t *testing.T) {
+	for _, tc := range []struct {
+		query    CredentialsQuery
+		expected string
+	}{
+		{DomainQuery("example.com"), `{"fqdn":"example.com","type":"domain"}`},
+		{EmailQuery("john@example.com"), `{"email":"john@example.com","type":"email"}`},
+		{UsernameQuery("john"), `{"type":"username","username":"john"}`},
+		{KeywordQuery("acme"), `{"keyword":"acme","type":"keyword"}`},
+		{PasswordHashQuery("5f4dcc3b"), `{"hash":"5f4dcc3b","type":"password_hash"}`},
+	} {
+		encoded, err := json.Marshal(tc.query)
+		assert.NoError(t, err)
+		assert.Equal(t, tc.expected, string(encoded))
+		assert.NoError(t, tc.query.validate())
+	}
+}
+
+func TestCredentialsQueryValidate(t *testing.T) {
+	assert.ErrorContains(t, DomainQuery(" ").validate(), "domain query has an empty fqdn")
+	assert.ErrorContains(t, EmailQuery("john").validate(), "invalid email address")
+	assert.ErrorContains(t, CredentialsQuery{}.validate(), "must be created with a query function")
+}
+
+// newCredentialsTest serves two pages of credentials for the
+// example.com domain.
+func newCredentialsTest(t *testing.T) *flareiotest.Server {
+	return flareiotest.NewServer(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/leaksdb/v2/credentials/_search", r.URL.Path)
+
+			type SearchBody struct {
+				Query map[string]string `json:"query"`
+				From  string            `json:"from"`
+			}
+			var body SearchBody
+			if err := json.NewDecoder(r.Body).Decode(&body); !assert.NoError(t, err, "Error decoding posted JSON") {
+				return
+			}
+			assert.Equal(t, map[string]string{"type": "domain", "fqdn": "example.com"}, body.Query)
+
+			if body.From == "" {
+				w.Write([]byte(`{"next": "second-page", "items": [{
+					"id": 1,
+					"identity_name": "john@example.com",
+					"domain": "example.com",
+					"hash": "hunter2",
+					"hash_type": "plaintext",
+					"imported_at": "2024-03-01T12:00:00Z",
+					"source": {
+						"id": "some-breach",
+						"name": "Some Breach",
+						"breached_at": "2023-12-25T00:00:00Z"
+					}
+				}]}`))
+			} else {
+				w.Write([]byte(`{"next": null, "items": [{
+					"id": 2,
+					"identity_name": "jane@example.com",
+					"hash": "5f4dcc3b5aa765d61d8327deb882cf99",
+					"hash_type": "md5",
+					"source": {"id": "other-breach"}
+				}]}`))
+			}
+		}),
+	)
+}
+
+func TestPagerCredentials(t *testing.T) {
+	server := newCredentialsTest(t)
+	defer server.Close()
+
+	pager, err := NewClient(server.Client()).PagerCredentials(
+		DomainQuery("example.com"),
+	)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	credentials := []*Credential{}
+	for pager.HasMore() {
+		if len(credentials) > 5 {
+			// We are going crazy here...
+			break
+		}
+		page, err := pager.Next(context.Background())
+		if !assert.NoError(t, err, "pager returned an error") {
+			return
+		}
+		credentials = append(credentials, page...)
+	}
+
+	if !assert.Len(t, credentials, 2) {
+		return
+	}
+	breachedAt := time.Date(2023, 12, 25, 0, 0, 0, 0, time.UTC)
+	assert.Equal(t, &Credential{
+		Id:           1,
+		IdentityName: "john@example.com",
+		Domain:       "example.com",
+		Hash:         "hunter2",
+		HashType:     HashTypePlaintext,
+		ImportedAt:   time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC),
+		Source: Source{
+			Id:         "some-breach",
+			Name:       "Some Breach",
+			BreachedAt: &breachedAt,
+		},
+	}, credentials[0])
+	assert.Equal(t, HashTypeMd5, credentials[1].HashType)
+	assert.Equal(t, "other-breach", credentials[1].Source.Id)
+	assert.Nil(t, credentials[1].Source.BreachedAt)
+}
+
+func TestPagerCredentialsInvalidItem(t *testing.T) {
+	server := flareiotest.NewServer(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var body map[string]interface{}
+			json.NewDecoder(r.Body).Decode(&body)
+			if body["from"] == nil {
+				w.Write([]byte(`{"next": "second-page", "items": [{"id": "not-a-number"}, {"id": 2}]}`))
+			} else {
+				w.Write([]byte(`{"next": null, "items": [{"id": 3}]}`))
+			}
+		}),
+	)
+	defer server.Close()
+
+	pager, err := NewClient(server.Client()).PagerCredentials(KeywordQuery("acme"))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	ids := []int64{}
+	errs := []error{}
+	for pager.HasMore() {
+		if len(ids) > 5 {
+			// We are going crazy here...
+			break
+		}
+		page, err := pager.Next(context.Background())
+		if err != nil {
+			errs = append(errs, err)
+		}
+		for _, credential := range page {
+			ids = append(ids, credential.Id)
+		}
+	}
+
+	assert.Equal(t, []int64{2, 3}, ids, "invalid items should not stop the pager")
+	if assert.Len(t, errs, 1) {
+		var itemErrs *flareio.ItemErrors
+		assert.ErrorAs(t, errs[0], &itemErrs)
+		assert.ErrorContains(t, errs[0], "item 0: failed to decode credential")
+	}
+}
+
+func TestPagerCredentialsInvalidQuery(t *testing.T) {
+	_, err := NewClient(nil).PagerCredentials(EmailQuery(""))
+	assert.ErrorContains(t, err, "email query has an empty email")
+}
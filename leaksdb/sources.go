@@ -0,0 +1,345 @@
+package leaksdb
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/Flared/go-flareio"
+	"github.com/Flared/go-flareio/internal/atomicfile"
+)
+
+const sourcesPath = "/leaksdb/v2/sources"
+
+// ErrSourceNotFound is returned when a source does not exist.
+var ErrSourceNotFound = errors.New("source not found")
+
+// Source is a leak in which credentials were found.
+type Source struct {
+	Id          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+
+	// BreachedAt is when the breach happened, if known.
+	BreachedAt *time.Time `json:"breached_at"`
+
+	// LeakedAt is when the data was made public, if known.
+	LeakedAt *time.Time `json:"leaked_at"`
+}
+
+// ListSources fetches the whole catalog of sources.
+func (client *Client) ListSources(ctx context.Context) ([]*Source, error) {
+	sources, err := flareio.ListAll[Source](ctx, client.apiClient, sourcesPath, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sources: %w", err)
+	}
+	return sources, nil
+}
+
+// GetSource fetches the source with the given id.
+//
+// ErrSourceNotFound is returned if it does not exist.
+func (client *Client) GetSource(ctx context.Context, id string) (*Source, error) {
+	var source Source
+	err := client.apiClient.DoJson(
+		ctx,
+		http.MethodGet,
+		sourcesPath+"/"+url.PathEscape(id),
+		nil,
+		&source,
+	)
+	var apiErr *flareio.ApiError
+	if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("%w: %q", ErrSourceNotFound, id)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch source: %w", err)
+	}
+	return &source, nil
+}
+
+// SourceResolver resolves source ids to their metadata using a cached
+// copy of the catalog of sources.
+//
+// The catalog is fetched once and refreshed when it expires. Until a
+// refresh succeeds, the expired catalog keeps being used, or the error
+// of the refresh is returned if there is none yet. Failed refreshes are
+// retried after at most a minute. Sources that
+// are missing from it, such as sources added since it was fetched, are
+// fetched individually and cached until the next refresh. It is safe
+// for concurrent use: requests are made without blocking the callers
+// that only need cached sources, and concurrent callers share them.
+type SourceResolver struct {
+	client    *Client
+	ttl       time.Duration
+	cacheFile string
+	now       func() time.Time
+
+	mu          sync.Mutex
+	sources     map[string]*Source
+	nextRefresh time.Time
+	refreshErr  error
+	refreshing  chan struct{}
+	fetching    map[string]*sourceFetch
+}
+
+// sourceFetch is a request for a single source that concurrent callers
+// can wait for.
+type sourceFetch struct {
+	done   chan struct{}
+	source *Source
+	err    error
+}
+
+// sourceRetryDelay is the longest that a resolver waits before trying
+// again to refresh its catalog after a failure.
+const sourceRetryDelay = time.Minute
+
+// SourceResolverOption configures a SourceResolver.
+type SourceResolverOption func(*SourceResolver)
+
+// WithSourceTTL allows configuring how long the catalog is cached
+// before being refreshed. Defaults to 24 hours. A ttl of zero or less
+// keeps the default.
+func WithSourceTTL(ttl time.Duration) SourceResolverOption {
+	return func(resolver *SourceResolver) {
+		if ttl > 0 {
+			resolver.ttl = ttl
+		}
+	}
+}
+
+// WithSourceCacheFile allows persisting the catalog at the given path,
+// so that it is not fetched again by the next resolver while it is
+// still fresh.
+func WithSourceCacheFile(path string) SourceResolverOption {
+	return func(resolver *SourceResolver) {
+		resolver.cacheFile = path
+	}
+}
+
+// NewSourceResolver can be used to create a new SourceResolver.
+func (client *Client) NewSourceResolver(
+	optionFns ...SourceResolverOption,
+) *SourceResolver {
+	resolver := &SourceResolver{
+		client:   client,
+		ttl:      time.Hour * 24,
+		now:      time.Now,
+		fetching: make(map[string]*sourceFetch),
+	}
+	for _, optionFn := range optionFns {
+		optionFn(resolver)
+	}
+	return resolver
+}
+
+// Resolve returns the source with the given id.
+//
+// ErrSourceNotFound is returned if it does not exist.
+func (resolver *SourceResolver) Resolve(ctx context.Context, id string) (*Source, error) {
+	if err := resolver.ensureCatalog(ctx); err != nil {
+		return nil, err
+	}
+
+	resolver.mu.Lock()
+	if source, cached := resolver.sources[id]; cached {
+		resolver.mu.Unlock()
+		if source == nil {
+			return nil, fmt.Errorf("%w: %q", ErrSourceNotFound, id)
+		}
+		return source, nil
+	}
+	fetch, inFlight := resolver.fetching[id]
+	if !inFlight {
+		fetch = &sourceFetch{
+			done: make(chan struct{}),
+		}
+		resolver.fetching[id] = fetch
+	}
+	resolver.mu.Unlock()
+
+	if inFlight {
+		select {
+		case <-fetch.done:
+			return fetch.source, fetch.err
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	source, err := resolver.client.GetSource(ctx, id)
+
+	resolver.mu.Lock()
+	delete(resolver.fetching, id)
+	if err == nil || errors.Is(err, ErrSourceNotFound) {
+		// Unknown sources are cached too, so that they are not
+		// requested again for every credential.
+		resolver.sources[id] = source
+	}
+	fetch.source, fetch.err = source, err
+	close(fetch.done)
+	resolver.mu.Unlock()
+
+	return source, err
+}
+
+// ResolveCredential replaces the source of the credential with the
+// metadata of its source.
+func (resolver *SourceResolver) ResolveCredential(
+	ctx context.Context,
+	credential *Credential,
+) error {
+	source, err := resolver.Resolve(ctx, credential.Source.Id)
+	if err != nil {
+		return err
+	}
+	credential.Source = *source
+	return nil
+}
+
+// ensureCatalog refreshes the catalog if it expired. Only one caller
+// refreshes it at a time, while the others keep using the expired
+// catalog or, if there is none yet, wait for it.
+func (resolver *SourceResolver) ensureCatalog(ctx context.Context) error {
+	for {
+		resolver.mu.Lock()
+		if resolver.now().Before(resolver.nextRefresh) {
+			// Without a catalog, the error of the last refresh is
+			// returned until it is retried.
+			err := resolver.refreshErr
+			if resolver.sources != nil {
+				err = nil
+			}
+			resolver.mu.Unlock()
+			return err
+		}
+		if resolver.refreshing == nil {
+			return resolver.refresh(ctx)
+		}
+		refreshing := resolver.refreshing
+		hasCatalog := resolver.sources != nil
+		resolver.mu.Unlock()
+
+		if hasCatalog {
+			return nil
+		}
+		select {
+		case <-refreshing:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// sourceCache is the content of the cache file.
+type sourceCache struct {
+	FetchedAt time.Time `json:"fetched_at"`
+	Sources   []*Source `json:"sources"`
+}
+
+// refresh must be called with mu held. It releases mu while the catalog
+// is fetched, and before returning.
+func (resolver *SourceResolver) refresh(ctx context.Context) error {
+	refreshing := make(chan struct{})
+	resolver.refreshing = refreshing
+	resolver.mu.Unlock()
+
+	cache, err := resolver.fetchCatalog(ctx)
+
+	resolver.mu.Lock()
+	defer resolver.mu.Unlock()
+	resolver.refreshing = nil
+	close(refreshing)
+
+	if err != nil {
+		if ctx.Err() != nil {
+			// The refresh was cancelled by its caller, so the next
+			// caller tries again right away.
+			return err
+		}
+		// Keep using the expired catalog if there is one, and try
+		// again later.
+		retryDelay := resolver.ttl
+		if retryDelay > sourceRetryDelay {
+			retryDelay = sourceRetryDelay
+		}
+		resolver.nextRefresh = resolver.now().Add(retryDelay)
+		resolver.refreshErr = err
+		if resolver.sources == nil {
+			return err
+		}
+		return nil
+	}
+
+	resolver.sources = make(map[string]*Source, len(cache.Sources))
+	for _, source := range cache.Sources {
+		resolver.sources[source.Id] = source
+	}
+	resolver.nextRefresh = cache.FetchedAt.Add(resolver.ttl)
+	resolver.refreshErr = nil
+	return nil
+}
+
+// fetchCatalog returns the persisted catalog if it is still fresh, and
+// fetches it otherwise.
+//
+// The cache file is only an optimization: a cache file that can't be
+// read is fetched again, and one that can't be written is ignored.
+func (resolver *SourceResolver) fetchCatalog(ctx context.Context) (*sourceCache, error) {
+	cache, err := resolver.loadCacheFile()
+	if err == nil && cache != nil && resolver.now().Sub(cache.FetchedAt) < resolver.ttl {
+		return cache, nil
+	}
+
+	fetchedAt := resolver.now()
+	sources, err := resolver.client.ListSources(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sources: %w", err)
+	}
+	cache = &sourceCache{
+		FetchedAt: fetchedAt,
+		Sources:   sources,
+	}
+	_ = resolver.saveCacheFile(cache)
+	return cache, nil
+}
+
+func (resolver *SourceResolver) loadCacheFile() (*sourceCache, error) {
+	if resolver.cacheFile == "" {
+		return nil, nil
+	}
+	data, err := os.ReadFile(resolver.cacheFile)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read source cache file: %w", err)
+	}
+	var cache sourceCache
+	if err := json.Unmarshal(data, &cache); err != nil {
+		return nil, fmt.Errorf("failed to decode source cache file: %w", err)
+	}
+	return &cache, nil
+}
+
+func (resolver *SourceResolver) saveCacheFile(cache *sourceCache) error {
+	if resolver.cacheFile == "" {
+		return nil
+	}
+	data, err := json.Marshal(cache)
+	if err != nil {
+		return fmt.Errorf("failed to encode source cache: %w", err)
+	}
+	if err := atomicfile.WriteFile(resolver.cacheFile, data); err != nil {
+		return fmt.Errorf("failed to write source cache file: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,334 @@
+package leaksdb
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Flared/go-flareio/flareiotest"
+	"github.com/stretchr/testify/assert"
+)
+
+// newSourcesTest serves a catalog of two sources, and a third source
+// that can only be fetched individually.
+func newSourcesTest(t *testing.T, requestedPaths *[]string) *flareiotest.Server {
+	return flareiotest.NewServer(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			*requestedPaths = append(*requestedPaths, r.URL.Path)
+			switch r.URL.Path {
+			case "/leaksdb/v2/sources":
+				if r.URL.Query().Get("from") == "" {
+					w.Write([]byte(`{"next": "second-page", "items": [
+						{"id": "some-breach", "name": "Some Breach", "breached_at": "2023-12-25T00:00:00Z"}
+					]}`))
+				} else {
+					w.Write([]byte(`{"next": null, "items": [
+						{"id": "other-breach", "name": "Other Breach"}
+					]}`))
+				}
+			case "/leaksdb/v2/sources/new-breach":
+				w.Write([]byte(`{"id": "new-breach", "name": "New Breach"}`))
+			default:
+				w.WriteHeader(http.StatusNotFound)
+			}
+		}),
+	)
+}
+
+func TestListSources(t *testing.T) {
+	requestedPaths := []string{}
+	server := newSourcesTest(t, &requestedPaths)
+	defer server.Close()
+
+	sources, err := NewClient(server.Client()).ListSources(context.Background())
+	if !assert.NoError(t, err) {
+		return
+	}
+	if !assert.Len(t, sources, 2) {
+		return
+	}
+	assert.Equal(t, "Some Breach", sources[0].Name)
+	assert.Equal(t, time.Date(2023, 12, 25, 0, 0, 0, 0, time.UTC), *sources[0].BreachedAt)
+	assert.Equal(t, "other-breach", sources[1].Id)
+}
+
+func TestGetSource(t *testing.T) {
+	requestedPaths := []string{}
+	server := newSourcesTest(t, &requestedPaths)
+	defer server.Close()
+
+	client := NewClient(server.Client())
+	source, err := client.GetSource(context.Background(), "new-breach")
+	if assert.NoError(t, err) {
+		assert.Equal(t, "New Breach", source.Name)
+	}
+
+	_, err = client.GetSource(context.Background(), "missing")
+	assert.ErrorIs(t, err, ErrSourceNotFound)
+}
+
+func TestSourceResolver(t *testing.T) {
+	requestedPaths := []string{}
+	server := newSourcesTest(t, &requestedPaths)
+	defer server.Close()
+
+	resolver := NewClient(server.Client()).NewSourceResolver()
+
+	for i := 0; i < 2; i++ {
+		source, err := resolver.Resolve(context.Background(), "other-breach")
+		if assert.NoError(t, err) {
+			assert.Equal(t, "Other Breach", source.Name)
+		}
+		source, err = resolver.Resolve(context.Background(), "new-breach")
+		if assert.NoError(t, err) {
+			assert.Equal(t, "New Breach", source.Name)
+		}
+		_, err = resolver.Resolve(context.Background(), "missing")
+		assert.ErrorIs(t, err, ErrSourceNotFound)
+	}
+
+	assert.Equal(
+		t,
+		[]string{
+			"/leaksdb/v2/sources",
+			"/leaksdb/v2/sources",
+			"/leaksdb/v2/sources/new-breach",
+			"/leaksdb/v2/sources/missing",
+		},
+		requestedPaths,
+		"sources should be cached",
+	)
+
+	credential := &Credential{
+		Id:     1,
+		Source: Source{Id: "some-breach"},
+	}
+	assert.NoError(t, resolver.ResolveCredential(context.Background(), credential))
+	assert.Equal(t, "Some Breach", credential.Source.Name)
+}
+
+func TestSourceResolverTTL(t *testing.T) {
+	requestedPaths := []string{}
+	server := newSourcesTest(t, &requestedPaths)
+	defer server.Close()
+
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	resolver := NewClient(server.Client()).NewSourceResolver(
+		WithSourceTTL(time.Hour),
+	)
+	resolver.now = func() time.Time { return now }
+
+	_, err := resolver.Resolve(context.Background(), "some-breach")
+	assert.NoError(t, err)
+	assert.Len(t, requestedPaths, 2)
+
+	now = now.Add(time.Minute * 59)
+	_, err = resolver.Resolve(context.Background(), "some-breach")
+	assert.NoError(t, err)
+	assert.Len(t, requestedPaths, 2, "fresh catalog should be used")
+
+	now = now.Add(time.Minute)
+	_, err = resolver.Resolve(context.Background(), "some-breach")
+	assert.NoError(t, err)
+	assert.Len(t, requestedPaths, 4, "expired catalog should be fetched again")
+}
+
+func TestSourceResolverInvalidTTL(t *testing.T) {
+	client := NewClient(nil)
+	for _, ttl := range []time.Duration{0, -time.Hour} {
+		resolver := client.NewSourceResolver(WithSourceTTL(ttl))
+		assert.Equal(t, time.Hour*24, resolver.ttl, "ttl %s", ttl)
+	}
+}
+
+func TestSourceResolverRefreshError(t *testing.T) {
+	failing := false
+	listRequests := 0
+	server := flareiotest.NewServer(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			listRequests = listRequests + 1
+			if failing {
+				w.WriteHeader(http.StatusForbidden)
+				return
+			}
+			w.Write([]byte(`{"next": null, "items": [{"id": "some-breach", "name": "Some Breach"}]}`))
+		}),
+	)
+	defer server.Close()
+
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	resolver := NewClient(server.Client()).NewSourceResolver()
+	resolver.now = func() time.Time { return now }
+
+	_, err := resolver.Resolve(context.Background(), "some-breach")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, listRequests)
+
+	// The expired catalog is used while it can't be refreshed.
+	failing = true
+	now = now.Add(time.Hour * 24)
+	source, err := resolver.Resolve(context.Background(), "some-breach")
+	if assert.NoError(t, err) {
+		assert.Equal(t, "Some Breach", source.Name)
+	}
+	assert.Equal(t, 2, listRequests)
+
+	_, err = resolver.Resolve(context.Background(), "some-breach")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, listRequests, "refresh should not be retried right away")
+
+	failing = false
+	now = now.Add(time.Minute)
+	_, err = resolver.Resolve(context.Background(), "some-breach")
+	assert.NoError(t, err)
+	assert.Equal(t, 3, listRequests, "refresh should be retried")
+}
+
+func TestSourceResolverConcurrent(t *testing.T) {
+	var mu sync.Mutex
+	requestedPaths := []string{}
+	release := make(chan struct{})
+	server := flareiotest.NewServer(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			mu.Lock()
+			requestedPaths = append(requestedPaths, r.URL.Path)
+			mu.Unlock()
+			switch r.URL.Path {
+			case "/leaksdb/v2/sources":
+				w.Write([]byte(`{"next": null, "items": [{"id": "some-breach", "name": "Some Breach"}]}`))
+			default:
+				<-release
+				w.Write([]byte(`{"id": "new-breach", "name": "New Breach"}`))
+			}
+		}),
+	)
+	defer server.Close()
+
+	resolver := NewClient(server.Client()).NewSourceResolver()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			source, err := resolver.Resolve(context.Background(), "new-breach")
+			if assert.NoError(t, err) {
+				assert.Equal(t, "New Breach", source.Name)
+			}
+		}()
+	}
+
+	// Cached sources are resolved while other sources are fetched.
+	source, err := resolver.Resolve(context.Background(), "some-breach")
+	if assert.NoError(t, err) {
+		assert.Equal(t, "Some Breach", source.Name)
+	}
+
+	close(release)
+	wg.Wait()
+
+	assert.Equal(
+		t,
+		[]string{
+			"/leaksdb/v2/sources",
+			"/leaksdb/v2/sources/new-breach",
+		},
+		requestedPaths,
+		"concurrent callers should share requests",
+	)
+}
+
+func TestSourceResolverCacheFile(t *testing.T) {
+	requestedPaths := []string{}
+	server := newSourcesTest(t, &requestedPaths)
+	defer server.Close()
+
+	cacheFile := filepath.Join(t.TempDir(), "sources.json")
+	client := NewClient(server.Client())
+
+	_, err := client.NewSourceResolver(
+		WithSourceCacheFile(cacheFile),
+	).Resolve(context.Background(), "some-breach")
+	assert.NoError(t, err)
+	assert.Len(t, requestedPaths, 2)
+
+	// A new resolver uses the persisted catalog.
+	source, err := client.NewSourceResolver(
+		WithSourceCacheFile(cacheFile),
+	).Resolve(context.Background(), "other-breach")
+	if assert.NoError(t, err) {
+		assert.Equal(t, "Other Breach", source.Name)
+	}
+	assert.Len(t, requestedPaths, 2, "persisted catalog should be used")
+}
+
+func TestSourceResolverInvalidCacheFile(t *testing.T) {
+	requestedPaths := []string{}
+	server := newSourcesTest(t, &requestedPaths)
+	defer server.Close()
+
+	client := NewClient(server.Client())
+	cacheFile := filepath.Join(t.TempDir(), "sources.json")
+	if !assert.NoError(t, os.WriteFile(cacheFile, []byte("{not json"), 0o600)) {
+		return
+	}
+
+	// A corrupt cache file is fetched again and replaced.
+	source, err := client.NewSourceResolver(
+		WithSourceCacheFile(cacheFile),
+	).Resolve(context.Background(), "some-breach")
+	if assert.NoError(t, err) {
+		assert.Equal(t, "Some Breach", source.Name)
+	}
+	assert.Len(t, requestedPaths, 2)
+
+	_, err = client.NewSourceResolver(
+		WithSourceCacheFile(cacheFile),
+	).Resolve(context.Background(), "some-breach")
+	assert.NoError(t, err)
+	assert.Len(t, requestedPaths, 2, "replaced cache file should be used")
+
+	// A cache file that can't be written doesn't fail the resolver.
+	resolver := client.NewSourceResolver(
+		WithSourceCacheFile(filepath.Join(t.TempDir(), "missing", "sources.json")),
+	)
+	for i := 0; i < 2; i++ {
+		source, err = resolver.Resolve(context.Background(), "other-breach")
+		if assert.NoError(t, err) {
+			assert.Equal(t, "Other Breach", source.Name)
+		}
+	}
+	assert.Len(t, requestedPaths, 4, "fetched catalog should be kept")
+}
+
+func TestSourceResolverListError(t *testing.T) {
+	requests := 0
+	server := flareiotest.NewServer(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests = requests + 1
+			w.WriteHeader(http.StatusForbidden)
+		}),
+	)
+	defer server.Close()
+
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	resolver := NewClient(server.Client()).NewSourceResolver()
+	resolver.now = func() time.Time { return now }
+
+	for i := 0; i < 2; i++ {
+		_, err := resolver.Resolve(context.Background(), "some-breach")
+		assert.ErrorContains(t, err, "failed to list sources")
+		assert.False(t, errors.Is(err, ErrSourceNotFound))
+	}
+	assert.Equal(t, 1, requests, "failed refresh should not be retried right away")
+
+	now = now.Add(time.Minute)
+	_, err := resolver.Resolve(context.Background(), "some-breach")
+	assert.Error(t, err)
+	assert.Equal(t, 2, requests, "failed refresh should be retried")
+}
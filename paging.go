@@ -0,0 +1,958 @@
+package flareio
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// ErrNoMorePages is returned by Pager.Next once the last page was fetched.
+var ErrNoMorePages = errors.New("no more pages")
+
+// ErrPagingLimitReached is matched by the errors returned when an
+// iteration stops because of a limit set with WithMaxPages,
+// WithMaxItems or WithMaxDuration.
+var ErrPagingLimitReached = errors.New("paging limit reached")
+
+// PagingLimitError is returned when an iteration stops because of a
+// limit while there were pages left to fetch.
+//
+// It is not returned when the limit is reached on the last page.
+type PagingLimitError struct {
+	// Limit describes the limit that was reached.
+	Limit string
+
+	// Cursor is the token of the next page, which can be used
+	// with WithStartCursor to resume the iteration.
+	Cursor string
+}
+
+func (e *PagingLimitError) Error() string {
+	return fmt.Sprintf("%s: %s", ErrPagingLimitReached, e.Limit)
+}
+
+func (e *PagingLimitError) Unwrap() error {
+	return ErrPagingLimitReached
+}
+
+// ErrCursorLoop is matched by the CursorLoopError returned when the
+// server sends a cursor that was already used during the iteration.
+var ErrCursorLoop = errors.New("cursor loop detected")
+
+// CursorLoopError is returned instead of requesting a cursor that was
+// already used during the iteration, which would otherwise make the
+// iteration go on forever.
+type CursorLoopError struct {
+	// Cursor is the token that was sent again by the server.
+	Cursor string
+}
+
+func (e *CursorLoopError) Error() string {
+	return fmt.Sprintf("%s: cursor %q was already fetched", ErrCursorLoop, e.Cursor)
+}
+
+func (e *CursorLoopError) Unwrap() error {
+	return ErrCursorLoop
+}
+
+// ErrEmptyPages is matched by the EmptyPagesError returned when the
+// server keeps sending empty pages.
+var ErrEmptyPages = errors.New("too many empty pages")
+
+// EmptyPagesError is returned when more consecutive empty pages than
+// allowed by WithMaxEmptyPages were received.
+type EmptyPagesError struct {
+	// EmptyPages is the number of consecutive empty pages received.
+	EmptyPages int
+
+	// Cursor is the token of the next page.
+	Cursor string
+}
+
+func (e *EmptyPagesError) Error() string {
+	return fmt.Sprintf("%s: got %d consecutive empty pages", ErrEmptyPages, e.EmptyPages)
+}
+
+func (e *EmptyPagesError) Unwrap() error {
+	return ErrEmptyPages
+}
+
+// IterResult contains results for a given page.
+type IterResult struct {
+	// Response associated with the fetched page.
+	//
+	// The response's body must be closed.
+	Response *http.Response
+
+	// Cursor is the token that was used to fetch this page.
+	//
+	// It is empty for the first page.
+	Cursor string
+
+	// Next is the token to be used to fetch the next page.
+	Next string
+
+	itemCount int
+	byteCount int64
+	total     int
+
+	// checkpoint is saved once the consumer is done with the page.
+	checkpoint string
+}
+
+type iterOptions struct {
+	ctx           context.Context
+	startCursor   string
+	checkpointer  Checkpointer
+	prefetchDepth int
+	maxPages      int
+	maxItems      int
+	maxDuration   time.Duration
+	maxEmptyPages int
+
+	pageRetryPolicy PageRetryPolicy
+	pagingStrategy  PagingStrategy
+	onProgress      func(Progress)
+	follow          *FollowPolicy
+
+	// now returns the current time, it is replaced by tests.
+	now func() time.Time
+}
+
+// IterOption configures the paging iterators and pagers.
+type IterOption func(*iterOptions)
+
+// WithStartCursor allows starting the iteration from a previously
+// obtained cursor instead of the first page.
+func WithStartCursor(cursor string) IterOption {
+	return func(options *iterOptions) {
+		options.startCursor = cursor
+	}
+}
+
+// WithCheckpointer allows persisting the progress of the iteration.
+//
+// If the checkpointer contains a saved cursor, the iteration resumes
+// from it instead of the start cursor. The checkpoint is updated with
+// the next cursor once the consumer is done with each page.
+//
+// Once the consumer is done with the last page, the checkpoint records
+// that the iteration completed, and an iteration resumed from it has
+// no pages to fetch. To start over, clear the checkpoint with
+// FileCheckpointer.Clear, or save an empty cursor with SaveCursor.
+func WithCheckpointer(checkpointer Checkpointer) IterOption {
+	return func(options *iterOptions) {
+		options.checkpointer = checkpointer
+	}
+}
+
+// WithContext allows configuring the context used by the paging
+// iterators for their requests.
+//
+// Pagers use the context given to Pager.Next instead.
+func WithContext(ctx context.Context) IterOption {
+	return func(options *iterOptions) {
+		options.ctx = ctx
+	}
+}
+
+// WithPrefetch allows fetching up to depth pages in the background
+// while the consumer processes the current page.
+//
+// Pages are still yielded in order, and the buffered pages are
+// released if the consumer stops early. It is only supported by the
+// paging iterators.
+func WithPrefetch(depth int) IterOption {
+	return func(options *iterOptions) {
+		options.prefetchDepth = depth
+	}
+}
+
+// WithMaxPages allows stopping the iteration after the given
+// number of pages.
+func WithMaxPages(maxPages int) IterOption {
+	return func(options *iterOptions) {
+		options.maxPages = maxPages
+	}
+}
+
+// WithMaxItems allows stopping the iteration once the given number
+// of items was fetched.
+//
+// Pages are never split, so the last page can bring the number of
+// items over the limit.
+func WithMaxItems(maxItems int) IterOption {
+	return func(options *iterOptions) {
+		options.maxItems = maxItems
+	}
+}
+
+// WithMaxDuration allows stopping the iteration once the given
+// duration has elapsed since the first page was requested.
+//
+// The limit is checked before each page is requested, so a request
+// in progress is never interrupted.
+func WithMaxDuration(maxDuration time.Duration) IterOption {
+	return func(options *iterOptions) {
+		options.maxDuration = maxDuration
+	}
+}
+
+// WithMaxEmptyPages allows stopping the iteration once more than the
+// given number of consecutive pages without items were received.
+//
+// Pages that have no items array are considered empty.
+func WithMaxEmptyPages(maxEmptyPages int) IterOption {
+	return func(options *iterOptions) {
+		options.maxEmptyPages = maxEmptyPages
+	}
+}
+
+func newIterOptions(optionFns []IterOption) *iterOptions {
+	options := &iterOptions{
+		ctx:            context.Background(),
+		pagingStrategy: CursorPaging{},
+		now:            time.Now,
+	}
+	for _, optionFn := range optionFns {
+		optionFn(options)
+	}
+	return options
+}
+
+// countsItems returns whether the items of buffered pages must be
+// counted. Only limits, progress reporting, empty page detection and
+// the paging strategies other than CursorPaging need the count.
+func (options *iterOptions) countsItems() bool {
+	if _, isCursorPaging := options.pagingStrategy.(CursorPaging); !isCursorPaging {
+		return true
+	}
+	return options.maxItems > 0 || options.onProgress != nil || options.maxEmptyPages > 0
+}
+
+// pageFetcher performs the request for the page at the given cursor.
+type pageFetcher func(ctx context.Context, cursor string) (*http.Response, error)
+
+// requestPage performs the request for the page at the given cursor
+// and makes sure that it succeeded.
+func requestPage(
+	ctx context.Context,
+	fetchPage pageFetcher,
+	cursor string,
+) (*http.Response, *PageError) {
+	response, err := fetchPage(ctx, cursor)
+	if err != nil {
+		return nil, &PageError{
+			Cursor: cursor,
+			Err:    fmt.Errorf("failed to fetch next page: %w", err),
+		}
+	}
+
+	if response.StatusCode != http.StatusOK {
+		defer response.Body.Close()
+		body, err := io.ReadAll(response.Body)
+		if err != nil {
+			return nil, &PageError{
+				Cursor:     cursor,
+				StatusCode: response.StatusCode,
+				Err:        fmt.Errorf("failed to read response: %w", err),
+			}
+		}
+		return nil, &PageError{
+			Cursor:     cursor,
+			StatusCode: response.StatusCode,
+			Err: fmt.Errorf(
+				"got http status code %d status while fetching next page: %s",
+				response.StatusCode,
+				body,
+			),
+		}
+	}
+
+	return response, nil
+}
+
+// getIterResult fetches the page at the given cursor and buffers its
+// body. Its items are only counted if countItems is set.
+func getIterResult(
+	ctx context.Context,
+	fetchPage pageFetcher,
+	strategy PagingStrategy,
+	cursor string,
+	countItems bool,
+) (*IterResult, *PageError) {
+	response, pageErr := requestPage(ctx, fetchPage, cursor)
+	if pageErr != nil {
+		return nil, pageErr
+	}
+	defer response.Body.Close()
+
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, &PageError{
+			Cursor:     cursor,
+			StatusCode: response.StatusCode,
+			Err:        fmt.Errorf("failed to read response: %w", err),
+		}
+	}
+
+	var fields map[string]json.RawMessage
+	itemCount := 0
+	if cursorPaging, isCursorPaging := strategy.(CursorPaging); isCursorPaging && !countItems && cursorPaging.usesNextField() {
+		fields, err = decodeCursorPageFields(body)
+	} else {
+		fields, itemCount, err = decodePageFields(body, countItems)
+	}
+	page := &PageResponse{
+		Cursor:    cursor,
+		Fields:    fields,
+		ItemCount: itemCount,
+	}
+	if err != nil {
+		// The next token may still be known if it was decoded
+		// before the error.
+		next, _ := strategy.NextCursor(page)
+		return nil, &PageError{
+			Cursor:     cursor,
+			Next:       next,
+			StatusCode: response.StatusCode,
+			Err:        fmt.Errorf("failed to unmarshal response: %w", err),
+		}
+	}
+	next, err := strategy.NextCursor(page)
+	if err != nil {
+		return nil, &PageError{
+			Cursor:     cursor,
+			StatusCode: response.StatusCode,
+			Err:        fmt.Errorf("failed to unmarshal response: %w", err),
+		}
+	}
+	response.Body = io.NopCloser(bytes.NewReader(body))
+
+	iterResult := &IterResult{
+		Response: response,
+		Cursor:   cursor,
+		Next:     next,
+
+		itemCount: itemCount,
+		byteCount: int64(len(body)),
+		total:     parseTotal(fields),
+	}
+
+	return iterResult, nil
+}
+
+// streamIterResult decodes the page at the given cursor as it is
+// received and passes its items to onItem.
+//
+// The body of the returned response has been consumed.
+func streamIterResult(
+	ctx context.Context,
+	fetchPage pageFetcher,
+	strategy PagingStrategy,
+	cursor string,
+	onItem func(item json.RawMessage) error,
+) (*IterResult, error) {
+	response, pageErr := requestPage(ctx, fetchPage, cursor)
+	if pageErr != nil {
+		return nil, pageErr
+	}
+	defer response.Body.Close()
+
+	body := &countingReader{reader: response.Body}
+	itemCount := 0
+	fields, err := decodePageStream(
+		body,
+		func(item json.RawMessage) error {
+			itemCount = itemCount + 1
+			if err := onItem(item); err != nil {
+				return &pageConsumerError{err: err}
+			}
+			return nil
+		},
+	)
+	var consumerErr *pageConsumerError
+	if errors.As(err, &consumerErr) {
+		return nil, consumerErr.err
+	}
+
+	page := &PageResponse{
+		Cursor:    cursor,
+		Fields:    fields,
+		ItemCount: itemCount,
+	}
+	if err != nil {
+		// The next token may still be known if it was decoded
+		// before the error.
+		next, _ := strategy.NextCursor(page)
+		return nil, &PageError{
+			Cursor:     cursor,
+			Next:       next,
+			StatusCode: response.StatusCode,
+			Err:        fmt.Errorf("failed to decode response: %w", err),
+
+			partial: itemCount > 0,
+		}
+	}
+
+	next, err := strategy.NextCursor(page)
+	if err != nil {
+		return nil, &PageError{
+			Cursor:     cursor,
+			StatusCode: response.StatusCode,
+			Err:        fmt.Errorf("failed to decode response: %w", err),
+
+			partial: itemCount > 0,
+		}
+	}
+
+	response.Body = http.NoBody
+
+	iterResult := &IterResult{
+		Response: response,
+		Cursor:   cursor,
+		Next:     next,
+
+		itemCount: itemCount,
+		byteCount: body.count,
+		total:     parseTotal(fields),
+	}
+
+	return iterResult, nil
+}
+
+// Pager fetches the pages of an API endpoint that supports the Flare
+// standard paging pattern one at a time.
+//
+// It provides the same features as the paging iterators on Go versions
+// that do not support ranging over functions.
+type Pager struct {
+	fetchPage pageFetcher
+	options   *iterOptions
+
+	started   bool
+	startedAt time.Time
+	done      bool
+	cursor    string
+
+	pagesFetched int
+	itemsFetched int
+	bytesFetched int64
+	requestsMade int
+	emptyPages   int
+	total        int
+
+	// seenCursors contains the cursors of the pages that were fetched.
+	seenCursors map[string]struct{}
+
+	// previous is the last page returned by Next, which is
+	// checkpointed once the consumer asks for the following page.
+	previous *IterResult
+
+	// failed is the last page error, which the consumer may have
+	// chosen to retry or skip.
+	failed *PageError
+
+	// pendingErr is returned by the next fetch. It is set when the
+	// checkpoint could not be loaded or marked as completed.
+	pendingErr error
+
+	// When following, followSkip is the number of items of the page at
+	// the cursor that were already processed, and followDelay is the
+	// delay before polling it.
+	followSkip      int
+	followIdlePolls int
+	followDelay     time.Duration
+}
+
+func newPager(
+	fetchPage pageFetcher,
+	options *iterOptions,
+) *Pager {
+	return &Pager{
+		fetchPage: fetchPage,
+		options:   options,
+		cursor:    options.startCursor,
+		total:     -1,
+
+		seenCursors: make(map[string]struct{}),
+	}
+}
+
+// HasMore returns whether there are pages left to fetch.
+//
+// It returns false after the last page or after Next returned an
+// error, unless the error was a PageError that the consumer chose
+// to retry or skip.
+//
+// With a checkpointer, the iteration is marked as completed when
+// HasMore returns false after the last page. If that fails, HasMore
+// returns true and the next call to Next returns the error.
+func (pager *Pager) HasMore() bool {
+	pager.resume()
+	pager.start()
+	pager.complete()
+	return !pager.done || pager.pendingErr != nil
+}
+
+// start loads the checkpoint before the first page is fetched.
+func (pager *Pager) start() {
+	if pager.started {
+		return
+	}
+	pager.started = true
+	pager.startedAt = pager.options.now()
+	if err := pager.loadCheckpoint(); err != nil {
+		pager.pendingErr = err
+	}
+}
+
+// complete checkpoints the last page returned by Next, which marks
+// the iteration as completed.
+func (pager *Pager) complete() {
+	previous := pager.previous
+	if !pager.done || previous == nil || previous.checkpoint != checkpointCompleted {
+		return
+	}
+	pager.previous = nil
+	if err := pager.options.saveCheckpoint(previous); err != nil {
+		pager.pendingErr = err
+	}
+}
+
+// resume applies the action chosen by the consumer for the last
+// page error.
+func (pager *Pager) resume() {
+	failed := pager.failed
+	if failed == nil {
+		return
+	}
+	switch failed.action {
+	case pageErrorRetry:
+		pager.cursor = failed.Cursor
+	case pageErrorSkip:
+		pager.cursor = failed.Next
+		pager.followSkip = 0
+	default:
+		return
+	}
+	pager.failed = nil
+	pager.done = false
+}
+
+// Next fetches the next page.
+//
+// Calling Next marks the previously returned page as processed.
+// ErrNoMorePages is returned if there are no pages left to fetch.
+func (pager *Pager) Next(ctx context.Context) (*IterResult, error) {
+	return pager.next(ctx, nil)
+}
+
+// NextStreaming fetches the next page and decodes it as it is received,
+// calling onItem for each element of its items array.
+//
+// Unlike Next, the page is never held in memory. The body of the
+// returned response has already been consumed. If onItem returns an
+// error, the pager stops and the error is returned as is.
+//
+// Pages that fail after some of their items were passed to onItem
+// are not retried automatically.
+func (pager *Pager) NextStreaming(
+	ctx context.Context,
+	onItem func(item json.RawMessage) error,
+) (*IterResult, error) {
+	return pager.next(ctx, onItem)
+}
+
+func (pager *Pager) next(
+	ctx context.Context,
+	onItem func(item json.RawMessage) error,
+) (*IterResult, error) {
+	if !pager.HasMore() {
+		return nil, ErrNoMorePages
+	}
+
+	previous := pager.previous
+	pager.previous = nil
+	if err := pager.options.saveCheckpoint(previous); err != nil {
+		pager.done = true
+		return nil, err
+	}
+
+	iterResult, err := pager.fetch(ctx, onItem)
+	if err != nil {
+		return nil, err
+	}
+	pager.previous = iterResult
+	return iterResult, nil
+}
+
+// fetch fetches the next page without marking the previous one
+// as processed. If onItem is not nil, the page is streamed to it.
+func (pager *Pager) fetch(
+	ctx context.Context,
+	onItem func(item json.RawMessage) error,
+) (*IterResult, error) {
+	if err := ctx.Err(); err != nil {
+		pager.done = true
+		return nil, err
+	}
+
+	pager.start()
+	if err := pager.pendingErr; err != nil {
+		pager.pendingErr = nil
+		pager.done = true
+		return nil, err
+	}
+
+	if limit := pager.reachedLimit(); limit != "" {
+		pager.done = true
+		return nil, &PagingLimitError{
+			Limit:  limit,
+			Cursor: pager.cursor,
+		}
+	}
+
+	follow := pager.options.follow
+	if follow != nil {
+		if onItem == nil {
+			pager.done = true
+			return nil, errors.New("following requires streaming the items")
+		}
+		if pager.followDelay > 0 {
+			if err := follow.wait(ctx, pager.followDelay); err != nil {
+				pager.done = true
+				return nil, fmt.Errorf("failed to wait before polling: %w", err)
+			}
+			pager.followDelay = 0
+		}
+	}
+
+	if err := pager.checkStalled(); err != nil {
+		pager.done = true
+		return nil, err
+	}
+
+	skipped := pager.followSkip
+	iterResult, err := pager.fetchWithRetries(ctx, onItem)
+	if err != nil {
+		pager.done = true
+		var pageErr *PageError
+		if follow != nil && errors.As(err, &pageErr) && pageErr.temporary() {
+			pageErr.Retry()
+			pager.followIdlePolls = pager.followIdlePolls + 1
+			pager.followDelay = follow.delay(pager.followIdlePolls)
+		}
+		return nil, err
+	}
+
+	itemCount := iterResult.itemCount
+	if follow != nil {
+		// Only the items that were not processed by a previous
+		// poll of the same page are new.
+		itemCount = pager.followSkip - skipped
+	}
+
+	pager.seenCursors[pager.cursor] = struct{}{}
+	pager.pagesFetched = pager.pagesFetched + 1
+	pager.itemsFetched = pager.itemsFetched + itemCount
+	pager.bytesFetched = pager.bytesFetched + iterResult.byteCount
+	if iterResult.total >= 0 {
+		pager.total = iterResult.total
+	}
+	if itemCount == 0 {
+		pager.emptyPages = pager.emptyPages + 1
+	} else {
+		pager.emptyPages = 0
+	}
+
+	if follow != nil {
+		if err := pager.advanceFollowing(iterResult, itemCount); err != nil {
+			pager.done = true
+			return nil, err
+		}
+	} else {
+		pager.cursor = iterResult.Next
+		pager.done = pager.cursor == ""
+		iterResult.checkpoint = iterResult.Next
+		if pager.done {
+			iterResult.checkpoint = checkpointCompleted
+		}
+	}
+
+	if pager.options.onProgress != nil {
+		pager.options.onProgress(pager.progress())
+	}
+
+	return iterResult, nil
+}
+
+func (pager *Pager) fetchWithRetries(
+	ctx context.Context,
+	onItem func(item json.RawMessage) error,
+) (*IterResult, error) {
+	policy := pager.options.pageRetryPolicy
+	for attempt := 1; ; attempt++ {
+		pager.requestsMade = pager.requestsMade + 1
+
+		var iterResult *IterResult
+		var err error
+		if pager.options.follow != nil {
+			iterResult, err = streamIterResult(
+				ctx,
+				pager.fetchPage,
+				pager.options.pagingStrategy,
+				pager.cursor,
+				pager.skipProcessedItems(onItem),
+			)
+		} else if onItem == nil {
+			var pageErr *PageError
+			iterResult, pageErr = getIterResult(
+				ctx,
+				pager.fetchPage,
+				pager.options.pagingStrategy,
+				pager.cursor,
+				pager.options.countsItems(),
+			)
+			if pageErr != nil {
+				err = pageErr
+			}
+		} else {
+			iterResult, err = streamIterResult(
+				ctx,
+				pager.fetchPage,
+				pager.options.pagingStrategy,
+				pager.cursor,
+				onItem,
+			)
+		}
+		if err == nil {
+			return iterResult, nil
+		}
+
+		var pageErr *PageError
+		if !errors.As(err, &pageErr) {
+			return nil, err
+		}
+		pageErr.Attempts = attempt
+		if pager.options.follow != nil {
+			// The items that were already processed are skipped
+			// when the page is requested again.
+			pageErr.partial = false
+		}
+		if attempt > policy.MaxRetries || !pageErr.temporary() {
+			pager.failed = pageErr
+			return nil, pageErr
+		}
+		if err := policy.wait(ctx, attempt); err != nil {
+			return nil, fmt.Errorf("failed to wait before retrying page: %w", err)
+		}
+	}
+}
+
+// reachedLimit returns a description of the limit that prevents
+// fetching the next page, if any.
+func (pager *Pager) reachedLimit() string {
+	options := pager.options
+	if options.maxPages > 0 && pager.pagesFetched >= options.maxPages {
+		return fmt.Sprintf("fetched %d pages", pager.pagesFetched)
+	}
+	if options.maxItems > 0 && pager.itemsFetched >= options.maxItems {
+		return fmt.Sprintf("fetched %d items", pager.itemsFetched)
+	}
+	if options.maxDuration > 0 && options.now().Sub(pager.startedAt) >= options.maxDuration {
+		return fmt.Sprintf("exceeded duration of %s", options.maxDuration)
+	}
+	return ""
+}
+
+// checkStalled returns an error if fetching the next page would not
+// make progress.
+func (pager *Pager) checkStalled() error {
+	if _, seen := pager.seenCursors[pager.cursor]; seen {
+		return &CursorLoopError{
+			Cursor: pager.cursor,
+		}
+	}
+	maxEmptyPages := pager.options.maxEmptyPages
+	if maxEmptyPages > 0 && pager.emptyPages > maxEmptyPages {
+		return &EmptyPagesError{
+			EmptyPages: pager.emptyPages,
+			Cursor:     pager.cursor,
+		}
+	}
+	return nil
+}
+
+func (pager *Pager) loadCheckpoint() error {
+	if pager.options.checkpointer == nil {
+		return nil
+	}
+	savedCursor, err := pager.options.checkpointer.LoadCursor()
+	if err != nil {
+		return fmt.Errorf("failed to load checkpoint: %w", err)
+	}
+	if savedCursor == "" {
+		return nil
+	}
+	if savedCursor == checkpointCompleted {
+		pager.done = true
+		return nil
+	}
+	if pager.options.follow != nil {
+		pager.cursor, pager.followSkip = decodeFollowCheckpoint(savedCursor)
+	} else {
+		pager.cursor = savedCursor
+	}
+	return nil
+}
+
+// saveCheckpoint records that the consumer is done with the given page.
+func (options *iterOptions) saveCheckpoint(iterResult *IterResult) error {
+	if options.checkpointer == nil || iterResult == nil || iterResult.checkpoint == "" {
+		return nil
+	}
+	if err := options.checkpointer.SaveCursor(iterResult.checkpoint); err != nil {
+		return fmt.Errorf("failed to save checkpoint: %w", err)
+	}
+	return nil
+}
+
+// cloneParams returns a copy of params that can be modified
+// without affecting the caller's values.
+func cloneParams(params *url.Values) url.Values {
+	cloned := url.Values{}
+	if params != nil {
+		for key, values := range *params {
+			cloned[key] = append([]string{}, values...)
+		}
+	}
+	return cloned
+}
+
+func (client *ApiClient) getPageFetcher(
+	path string,
+	params *url.Values,
+	strategy PagingStrategy,
+) pageFetcher {
+	return func(ctx context.Context, cursor string) (*http.Response, error) {
+		pageRequest := &PageRequest{
+			Params: cloneParams(params),
+		}
+		if err := strategy.InjectCursor(pageRequest, cursor); err != nil {
+			return nil, &pageRequestError{
+				err: fmt.Errorf("failed to inject cursor: %w", err),
+			}
+		}
+		return client.GetWithContext(
+			ctx,
+			path,
+			&pageRequest.Params,
+		)
+	}
+}
+
+// jsonObjectFields encodes the body and returns the fields of the
+// resulting JSON object. A nil body is an empty object.
+func jsonObjectFields(body interface{}) (map[string]json.RawMessage, error) {
+	encodedJson, err := json.Marshal(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal body to JSON: %w", err)
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(encodedJson, &fields); err != nil {
+		return nil, fmt.Errorf("body must be a JSON object: %w", err)
+	}
+	if fields == nil {
+		fields = make(map[string]json.RawMessage)
+	}
+	return fields, nil
+}
+
+func (client *ApiClient) postJsonPageFetcher(
+	path string,
+	params *url.Values,
+	body interface{},
+	strategy PagingStrategy,
+) pageFetcher {
+	// The body is encoded once so that later changes to the caller's
+	// value don't affect the following pages.
+	bodyFields, bodyErr := jsonObjectFields(body)
+
+	return func(ctx context.Context, cursor string) (*http.Response, error) {
+		if bodyErr != nil {
+			return nil, &pageRequestError{err: bodyErr}
+		}
+
+		pageRequest := &PageRequest{
+			Params: cloneParams(params),
+			Body:   make(map[string]interface{}, len(bodyFields)+1),
+		}
+		for key, value := range bodyFields {
+			pageRequest.Body[key] = value
+		}
+		if err := strategy.InjectCursor(pageRequest, cursor); err != nil {
+			return nil, &pageRequestError{
+				err: fmt.Errorf("failed to inject cursor: %w", err),
+			}
+		}
+
+		encodedJson, err := json.Marshal(pageRequest.Body)
+		if err != nil {
+			return nil, &pageRequestError{
+				err: fmt.Errorf("failed to marshal body to JSON: %w", err),
+			}
+		}
+
+		return client.PostWithContext(
+			ctx,
+			path,
+			&pageRequest.Params,
+			"application/json",
+			bytes.NewReader(encodedJson),
+		)
+	}
+}
+
+// PagerGet returns a Pager over the responses of an API endpoint that
+// supports the Flare standard paging pattern.
+//
+// The params are copied for every page and are never modified.
+func (client *ApiClient) PagerGet(
+	path string,
+	params *url.Values,
+	optionFns ...IterOption,
+) *Pager {
+	options := newIterOptions(optionFns)
+	return newPager(
+		client.getPageFetcher(path, params, options.pagingStrategy),
+		options,
+	)
+}
+
+// PagerPostJson returns a Pager over the responses of an API endpoint
+// that supports the Flare standard paging pattern.
+//
+// The body can be any value that encodes to a JSON object, such as a
+// map, a struct or a json.RawMessage. It is encoded when the pager is
+// created, and the cursor is added to the encoded object. The params
+// and body are never modified.
+func (client *ApiClient) PagerPostJson(
+	path string,
+	params *url.Values,
+	body interface{},
+	optionFns ...IterOption,
+) *Pager {
+	options := newIterOptions(optionFns)
+	return newPager(
+		client.postJsonPageFetcher(path, params, body, options.pagingStrategy),
+		options,
+	)
+}
@@ -0,0 +1,119 @@
+package flareio
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+)
+
+// ItemError describes an item of a page that could not be processed.
+type ItemError struct {
+	// Index is the position of the item in its page.
+	Index int
+
+	Err error
+}
+
+func (e *ItemError) Error() string {
+	return fmt.Sprintf("item %d: %s", e.Index, e.Err)
+}
+
+func (e *ItemError) Unwrap() error {
+	return e.Err
+}
+
+// ItemErrors is returned along with the items of a page that could be
+// processed when some of its other items could not. The pager that
+// returned it can continue with the next page.
+type ItemErrors struct {
+	Errors []*ItemError
+}
+
+func (e *ItemErrors) Error() string {
+	if len(e.Errors) == 1 {
+		return e.Errors[0].Error()
+	}
+	return fmt.Sprintf("%d items failed, first error: %s", len(e.Errors), e.Errors[0])
+}
+
+// Unwrap returns the errors of the items. errors.Is and errors.As only
+// use it from Go 1.20: on older versions, match the errors of Errors
+// one at a time instead.
+func (e *ItemErrors) Unwrap() []error {
+	errs := make([]error, 0, len(e.Errors))
+	for _, itemErr := range e.Errors {
+		errs = append(errs, itemErr)
+	}
+	return errs
+}
+
+// NextDecoded fetches the next page of the pager and decodes each of
+// its items with decode.
+//
+// Items that cannot be decoded don't stop the pager: the other items
+// are returned along with an *ItemErrors. Other errors are returned
+// like Pager.NextStreaming does.
+func NextDecoded[T any](
+	ctx context.Context,
+	pager *Pager,
+	decode func(item json.RawMessage) (T, error),
+) ([]T, error) {
+	items := []T{}
+	itemErrs := &ItemErrors{}
+	index := 0
+	_, err := pager.NextStreaming(
+		ctx,
+		func(item json.RawMessage) error {
+			decoded, err := decode(item)
+			if err != nil {
+				itemErrs.Errors = append(itemErrs.Errors, &ItemError{
+					Index: index,
+					Err:   err,
+				})
+			} else {
+				items = append(items, decoded)
+			}
+			index = index + 1
+			return nil
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	if len(itemErrs.Errors) > 0 {
+		return items, itemErrs
+	}
+	return items, nil
+}
+
+// ListAll fetches every page of the items at the given path and
+// decodes each item into a T.
+//
+// Unlike NextDecoded, it fails if an item cannot be decoded.
+func ListAll[T any](
+	ctx context.Context,
+	client *ApiClient,
+	path string,
+	params *url.Values,
+) ([]*T, error) {
+	pager := client.PagerGet(path, params)
+	items := []*T{}
+	for pager.HasMore() {
+		_, err := pager.NextStreaming(
+			ctx,
+			func(item json.RawMessage) error {
+				var decoded T
+				if err := json.Unmarshal(item, &decoded); err != nil {
+					return fmt.Errorf("failed to decode item: %w", err)
+				}
+				items = append(items, &decoded)
+				return nil
+			},
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return items, nil
+}
@@ -0,0 +1,34 @@
+//go:build go1.23
+
+package flareio
+
+import (
+	"encoding/json"
+	"iter"
+)
+
+// IterDecoded allows to iterate over the items of an iterator such as
+// IterGetItems, decoded with decode.
+//
+// An item that cannot be decoded is yielded as an error, like the
+// errors of items, and the iteration continues if the consumer doesn't
+// stop.
+func IterDecoded[T any](
+	items iter.Seq2[json.RawMessage, error],
+	decode func(item json.RawMessage) (T, error),
+) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		for item, err := range items {
+			if err != nil {
+				var zero T
+				if !yield(zero, err) {
+					return
+				}
+				continue
+			}
+			if !yield(decode(item)) {
+				return
+			}
+		}
+	}
+}
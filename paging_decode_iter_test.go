@@ -0,0 +1,56 @@
+//go:build go1.23
+
+package flareio
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIterDecoded(t *testing.T) {
+	pageErr := errors.New("failed to fetch page")
+	items := func(yield func(json.RawMessage, error) bool) {
+		for _, item := range []struct {
+			raw string
+			err error
+		}{
+			{raw: "1"},
+			{raw: `"two"`},
+			{err: pageErr},
+			{raw: "3"},
+		} {
+			if !yield(json.RawMessage(item.raw), item.err) {
+				return
+			}
+		}
+	}
+	decodeInt := func(item json.RawMessage) (int, error) {
+		var value int
+		err := json.Unmarshal(item, &value)
+		return value, err
+	}
+
+	values := []int{}
+	errs := []error{}
+	for value, err := range IterDecoded(items, decodeInt) {
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		values = append(values, value)
+	}
+	assert.Equal(t, []int{1, 3}, values, "the iteration should continue after errors")
+	if assert.Len(t, errs, 2) {
+		var typeErr *json.UnmarshalTypeError
+		assert.ErrorAs(t, errs[0], &typeErr)
+		assert.ErrorIs(t, errs[1], pageErr)
+	}
+
+	for value := range IterDecoded(items, decodeInt) {
+		assert.Equal(t, 1, value)
+		break
+	}
+}
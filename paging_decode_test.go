@@ -0,0 +1,86 @@
+package flareio
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNextDecoded(t *testing.T) {
+	ct := newClientTest(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Query().Get("from") == "" {
+				w.Write([]byte(`{"next": "second-page", "items": [1, "two", 3, "four"]}`))
+			} else {
+				w.Write([]byte(`{"next": null, "items": [5]}`))
+			}
+		}),
+	)
+	defer ct.Close()
+
+	decodeInt := func(item json.RawMessage) (int, error) {
+		var value int
+		err := json.Unmarshal(item, &value)
+		return value, err
+	}
+
+	pager := ct.apiClient.PagerGet("/leaksdb/sources", nil)
+	items, err := NextDecoded(context.Background(), pager, decodeInt)
+	assert.Equal(t, []int{1, 3}, items, "the valid items should be returned")
+
+	var itemErrs *ItemErrors
+	if assert.ErrorAs(t, err, &itemErrs) && assert.Len(t, itemErrs.Errors, 2) {
+		assert.Equal(t, 1, itemErrs.Errors[0].Index)
+		assert.Equal(t, 3, itemErrs.Errors[1].Index)
+	}
+	assert.ErrorContains(t, err, "2 items failed, first error: item 1: json: cannot unmarshal string")
+
+	var typeErr *json.UnmarshalTypeError
+	assert.True(t, errors.As(itemErrs.Errors[0], &typeErr), "item errors should wrap the decode error")
+
+	assert.True(t, pager.HasMore(), "the pager should continue after item errors")
+	items, err = NextDecoded(context.Background(), pager, decodeInt)
+	if assert.NoError(t, err) {
+		assert.Equal(t, []int{5}, items)
+	}
+	assert.False(t, pager.HasMore())
+}
+
+func TestListAll(t *testing.T) {
+	ct := newClientTest(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/leaksdb/invalid" {
+				w.Write([]byte(`{"next": null, "items": [{"id": "one"}]}`))
+				return
+			}
+			assert.Equal(t, "/leaksdb/sources", r.URL.Path)
+			assert.Equal(t, "acme", r.URL.Query().Get("search"))
+			if r.URL.Query().Get("from") == "" {
+				w.Write([]byte(`{"next": "second-page", "items": [{"id": 1, "name": "First"}]}`))
+			} else {
+				w.Write([]byte(`{"next": null, "items": [{"id": 2, "name": "Second"}]}`))
+			}
+		}),
+	)
+	defer ct.Close()
+
+	type source struct {
+		Id   int    `json:"id"`
+		Name string `json:"name"`
+	}
+
+	params := &url.Values{}
+	params.Set("search", "acme")
+	sources, err := ListAll[source](context.Background(), ct.apiClient, "/leaksdb/sources", params)
+	if assert.NoError(t, err) {
+		assert.Equal(t, []*source{{Id: 1, Name: "First"}, {Id: 2, Name: "Second"}}, sources)
+	}
+
+	_, err = ListAll[source](context.Background(), ct.apiClient, "/leaksdb/invalid", nil)
+	assert.ErrorContains(t, err, "failed to decode item: json: cannot unmarshal string")
+}
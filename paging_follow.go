@@ -0,0 +1,166 @@
+package flareio
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"strings"
+	"time"
+)
+
+// FollowPolicy configures how an iteration keeps polling for new
+// items once it caught up with the last page, like tail -f.
+type FollowPolicy struct {
+	// Interval is the delay before polling again once the iteration
+	// caught up. Defaults to 30 seconds.
+	Interval time.Duration
+
+	// MaxInterval is the maximum delay between two polls. The delay is
+	// doubled after each poll that brings no new items or that fails.
+	// Defaults to 5 minutes.
+	MaxInterval time.Duration
+
+	// Jitter is the fraction of the delay that is randomly added or
+	// removed, such as 0.1 for up to 10%.
+	Jitter float64
+}
+
+// WithFollow allows the iteration to keep polling for new items after
+// the last page instead of stopping, until its context is cancelled.
+//
+// When the server doesn't send a next cursor, the last page is polled
+// again and the items that were already yielded are skipped. Pages that
+// fail with a temporary error are retried after the polling delay once
+// the error was yielded.
+//
+// It is only supported by the item iterators and Pager.NextStreaming.
+// With a checkpointer, a restarted iteration resumes after the last
+// item of the last page that was processed.
+func WithFollow(policy FollowPolicy) IterOption {
+	return func(options *iterOptions) {
+		options.follow = &policy
+	}
+}
+
+// delay returns the delay before the next poll after the given number
+// of consecutive polls that brought no new items or failed.
+func (policy FollowPolicy) delay(idlePolls int) time.Duration {
+	interval := policy.Interval
+	if interval <= 0 {
+		interval = time.Second * 30
+	}
+	maxInterval := policy.MaxInterval
+	if maxInterval <= 0 {
+		maxInterval = time.Minute * 5
+	}
+	if maxInterval < interval {
+		maxInterval = interval
+	}
+
+	delay := maxInterval
+	if idlePolls < 32 {
+		if backoff := interval << idlePolls; backoff > 0 && backoff < maxInterval {
+			delay = backoff
+		}
+	}
+
+	if policy.Jitter > 0 {
+		jitter := float64(delay) * policy.Jitter * (rand.Float64()*2 - 1)
+		delay = delay + time.Duration(jitter)
+	}
+	return delay
+}
+
+func (policy FollowPolicy) wait(ctx context.Context, delay time.Duration) error {
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// followCheckpoint is saved instead of a plain cursor when some items
+// of the page at the cursor were already processed.
+type followCheckpoint struct {
+	Cursor string `json:"cursor"`
+	Skip   int    `json:"skip"`
+}
+
+func encodeFollowCheckpoint(cursor string, skip int) (string, error) {
+	if skip == 0 {
+		return cursor, nil
+	}
+	encoded, err := json.Marshal(followCheckpoint{
+		Cursor: cursor,
+		Skip:   skip,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to encode checkpoint: %w", err)
+	}
+	return string(encoded), nil
+}
+
+// decodeFollowCheckpoint also accepts plain cursors, such as the ones
+// saved by iterations that were not following.
+func decodeFollowCheckpoint(saved string) (string, int) {
+	if !strings.HasPrefix(saved, "{") {
+		return saved, 0
+	}
+	var checkpoint followCheckpoint
+	if err := json.Unmarshal([]byte(saved), &checkpoint); err != nil {
+		return saved, 0
+	}
+	return checkpoint.Cursor, checkpoint.Skip
+}
+
+// skipProcessedItems wraps onItem so that the items of the page at the
+// cursor that were already processed are not passed to it again.
+func (pager *Pager) skipProcessedItems(
+	onItem func(item json.RawMessage) error,
+) func(item json.RawMessage) error {
+	skip := pager.followSkip
+	return func(item json.RawMessage) error {
+		if skip > 0 {
+			skip = skip - 1
+			return nil
+		}
+		pager.followSkip = pager.followSkip + 1
+		return onItem(item)
+	}
+}
+
+// advanceFollowing moves to the next page if there is one, or stays on
+// the current page and schedules the next poll once caught up.
+func (pager *Pager) advanceFollowing(iterResult *IterResult, newItems int) error {
+	next := iterResult.Next
+	moved := next != "" && next != pager.cursor
+	if moved {
+		pager.cursor = next
+		pager.followSkip = 0
+	}
+
+	if newItems == 0 {
+		pager.followIdlePolls = pager.followIdlePolls + 1
+	} else {
+		pager.followIdlePolls = 0
+	}
+
+	if !moved || newItems == 0 {
+		pager.followDelay = pager.options.follow.delay(pager.followIdlePolls)
+
+		// Polling the same cursors again is expected once caught up.
+		pager.seenCursors = make(map[string]struct{})
+		pager.emptyPages = 0
+	}
+
+	checkpoint, err := encodeFollowCheckpoint(pager.cursor, pager.followSkip)
+	if err != nil {
+		return err
+	}
+	iterResult.checkpoint = checkpoint
+	return nil
+}
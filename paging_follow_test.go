@@ -0,0 +1,148 @@
+package flareio
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFollowPolicyDelay(t *testing.T) {
+	policy := FollowPolicy{
+		Interval:    time.Second,
+		MaxInterval: time.Second * 4,
+	}
+	assert.Equal(t, time.Second, policy.delay(0))
+	assert.Equal(t, time.Second*2, policy.delay(1))
+	assert.Equal(t, time.Second*4, policy.delay(2))
+	assert.Equal(t, time.Second*4, policy.delay(50))
+
+	assert.Equal(t, time.Second*30, FollowPolicy{}.delay(0))
+	assert.Equal(t, time.Minute*5, FollowPolicy{}.delay(50))
+
+	policy.Jitter = 0.5
+	for i := 0; i < 20; i++ {
+		delay := policy.delay(0)
+		assert.GreaterOrEqual(t, delay, time.Millisecond*500)
+		assert.LessOrEqual(t, delay, time.Millisecond*1500)
+	}
+}
+
+func TestFollowCheckpoint(t *testing.T) {
+	encoded, err := encodeFollowCheckpoint("some-cursor", 0)
+	assert.NoError(t, err)
+	assert.Equal(t, "some-cursor", encoded)
+
+	encoded, err = encodeFollowCheckpoint("some-cursor", 3)
+	assert.NoError(t, err)
+	cursor, skip := decodeFollowCheckpoint(encoded)
+	assert.Equal(t, "some-cursor", cursor)
+	assert.Equal(t, 3, skip)
+
+	cursor, skip = decodeFollowCheckpoint("plain-cursor")
+	assert.Equal(t, "plain-cursor", cursor)
+	assert.Equal(t, 0, skip)
+}
+
+// newGrowingPageTest serves a single page without a next cursor, which
+// gains one item every time it is requested.
+func newGrowingPageTest(requestedCursors *[]string) *clientTest {
+	return newClientTest(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			*requestedCursors = append(*requestedCursors, r.URL.Query().Get("from"))
+			items := []string{}
+			for i := range *requestedCursors {
+				items = append(items, fmt.Sprintf("%d", i+1))
+			}
+			w.Write([]byte(
+				fmt.Sprintf(`{"next": null, "items": [%s]}`, strings.Join(items, ",")),
+			))
+		}),
+	)
+}
+
+func TestPagerFollow(t *testing.T) {
+	requestedCursors := []string{}
+	ct := newGrowingPageTest(&requestedCursors)
+	defer ct.Close()
+
+	checkpointer := NewFileCheckpointer(
+		filepath.Join(t.TempDir(), "checkpoint"),
+	)
+
+	pager := ct.apiClient.PagerGet(
+		"/leaksdb/sources",
+		nil,
+		WithCheckpointer(checkpointer),
+		WithFollow(FollowPolicy{
+			Interval: time.Millisecond,
+		}),
+	)
+
+	items := []string{}
+	for pager.HasMore() && len(items) < 3 {
+		_, err := pager.NextStreaming(
+			context.Background(),
+			func(item json.RawMessage) error {
+				items = append(items, string(item))
+				return nil
+			},
+		)
+		if !assert.NoError(t, err, "pager returned an error") {
+			return
+		}
+	}
+
+	assert.Equal(t, []string{"1", "2", "3"}, items, "items should not be replayed")
+	assert.Equal(t, []string{"", "", ""}, requestedCursors)
+
+	// Mark the last page as processed.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := pager.NextStreaming(ctx, func(item json.RawMessage) error {
+		return nil
+	})
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.False(t, pager.HasMore())
+
+	// A restarted pager resumes after the processed items.
+	resumed := ct.apiClient.PagerGet(
+		"/leaksdb/sources",
+		nil,
+		WithCheckpointer(checkpointer),
+		WithFollow(FollowPolicy{
+			Interval: time.Millisecond,
+		}),
+	)
+	items = []string{}
+	_, err = resumed.NextStreaming(
+		context.Background(),
+		func(item json.RawMessage) error {
+			items = append(items, string(item))
+			return nil
+		},
+	)
+	assert.NoError(t, err, "pager returned an error")
+	assert.Equal(t, []string{"4"}, items)
+}
+
+func TestPagerFollowRequiresStreaming(t *testing.T) {
+	requestedCursors := []string{}
+	ct := newGrowingPageTest(&requestedCursors)
+	defer ct.Close()
+
+	pager := ct.apiClient.PagerGet(
+		"/leaksdb/sources",
+		nil,
+		WithFollow(FollowPolicy{}),
+	)
+	_, err := pager.Next(context.Background())
+	assert.ErrorContains(t, err, "following requires streaming the items")
+	assert.Empty(t, requestedCursors)
+}
@@ -0,0 +1,119 @@
+package flareio
+
+import (
+	"encoding/json"
+	"io"
+	"time"
+)
+
+// Progress describes the progress of a paging iteration.
+type Progress struct {
+	// Pages is the number of pages fetched.
+	Pages int
+
+	// Items is the number of items in the fetched pages.
+	Items int
+
+	// Bytes is the size of the bodies of the fetched pages.
+	Bytes int64
+
+	// Requests is the number of page requests, including retries.
+	Requests int
+
+	// Elapsed is the time since the first page was requested.
+	Elapsed time.Duration
+
+	// RequestRate is the number of page requests per second.
+	RequestRate float64
+
+	// Total is the total number of items reported by the server in
+	// the "total" field of the last page, or -1 if it isn't known.
+	Total int
+
+	// PercentComplete is the percentage of the total number of items
+	// that was fetched, or 0 if the total isn't known.
+	PercentComplete float64
+
+	// EstimatedFinish is when the iteration is expected to finish
+	// at the current rate. It is zero if the total isn't known.
+	EstimatedFinish time.Time
+}
+
+// WithProgress allows being notified of the progress of the iteration
+// after each page is fetched.
+//
+// When prefetching, onProgress is called from the goroutine that
+// fetches the pages.
+func WithProgress(onProgress func(Progress)) IterOption {
+	return func(options *iterOptions) {
+		options.onProgress = onProgress
+	}
+}
+
+func (pager *Pager) progress() Progress {
+	elapsed := pager.options.now().Sub(pager.startedAt)
+	progress := Progress{
+		Pages:    pager.pagesFetched,
+		Items:    pager.itemsFetched,
+		Bytes:    pager.bytesFetched,
+		Requests: pager.requestsMade,
+		Elapsed:  elapsed,
+		Total:    -1,
+	}
+	if elapsed > 0 {
+		progress.RequestRate = float64(pager.requestsMade) / elapsed.Seconds()
+	}
+	if pager.total < 0 {
+		return progress
+	}
+
+	progress.Total = pager.total
+	progress.PercentComplete = 100
+	if pager.total > pager.itemsFetched {
+		progress.PercentComplete = float64(pager.itemsFetched) * 100 / float64(pager.total)
+	}
+	if pager.itemsFetched > 0 {
+		expected := time.Duration(
+			float64(elapsed) * float64(pager.total) / float64(pager.itemsFetched),
+		)
+		progress.EstimatedFinish = pager.startedAt.Add(expected)
+	}
+	return progress
+}
+
+// parseTotal returns the total number of items from the top level
+// "total" field of a page. Both numbers and objects with a "value"
+// number are supported, or -1 if there is no total.
+func parseTotal(fields map[string]json.RawMessage) int {
+	rawTotal, ok := fields["total"]
+	if !ok {
+		return -1
+	}
+
+	var total int
+	if err := json.Unmarshal(rawTotal, &total); err == nil {
+		return total
+	}
+
+	type TotalWithValue struct {
+		Value *int `json:"value"`
+	}
+	var totalWithValue TotalWithValue
+	if err := json.Unmarshal(rawTotal, &totalWithValue); err == nil && totalWithValue.Value != nil {
+		return *totalWithValue.Value
+	}
+
+	return -1
+}
+
+// countingReader counts the bytes read from the underlying reader.
+type countingReader struct {
+	reader io.Reader
+	count  int64
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	r.count = r.count + int64(n)
+	return n, err
+}
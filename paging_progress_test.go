@@ -0,0 +1,120 @@
+package flareio
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseTotal(t *testing.T) {
+	assert.Equal(t, 42, parseTotal(map[string]json.RawMessage{
+		"total": json.RawMessage(`42`),
+	}))
+	assert.Equal(t, 42, parseTotal(map[string]json.RawMessage{
+		"total": json.RawMessage(`{"value": 42, "relation": "eq"}`),
+	}))
+	assert.Equal(t, -1, parseTotal(map[string]json.RawMessage{
+		"total": json.RawMessage(`"many"`),
+	}))
+	assert.Equal(t, -1, parseTotal(map[string]json.RawMessage{}))
+}
+
+func TestPagerProgress(t *testing.T) {
+	pages := []string{
+		`{"next": "second-page", "items": [1, 2], "total": {"value": 4}}`,
+		`{"next": null, "items": [3, 4], "total": {"value": 4}}`,
+	}
+	ct := newClientTest(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Query().Get("from") == "" {
+				w.Write([]byte(pages[0]))
+			} else {
+				w.Write([]byte(pages[1]))
+			}
+		}),
+	)
+	defer ct.Close()
+
+	reports := []Progress{}
+	pager := ct.apiClient.PagerGet(
+		"/leaksdb/sources",
+		nil,
+		WithProgress(func(progress Progress) {
+			reports = append(reports, progress)
+		}),
+	)
+
+	start := time.Now()
+	for pager.HasMore() {
+		if len(reports) > 5 {
+			// We are going crazy here...
+			break
+		}
+		if _, err := pager.Next(context.Background()); !assert.NoError(t, err, "pager returned an error") {
+			return
+		}
+	}
+
+	if !assert.Len(t, reports, 2, "progress should be reported after each page") {
+		return
+	}
+
+	first := reports[0]
+	assert.Equal(t, 1, first.Pages)
+	assert.Equal(t, 2, first.Items)
+	assert.Equal(t, int64(len(pages[0])), first.Bytes)
+	assert.Equal(t, 1, first.Requests)
+	assert.Equal(t, 4, first.Total)
+	assert.Equal(t, float64(50), first.PercentComplete)
+	assert.False(t, first.EstimatedFinish.IsZero(), "the finish time should be estimated")
+	assert.True(t, first.EstimatedFinish.After(start))
+
+	last := reports[1]
+	assert.Equal(t, 2, last.Pages)
+	assert.Equal(t, 4, last.Items)
+	assert.Equal(t, int64(len(pages[0])+len(pages[1])), last.Bytes)
+	assert.Equal(t, float64(100), last.PercentComplete)
+	assert.Greater(t, last.RequestRate, float64(0))
+}
+
+func TestPagerProgressStreamingWithoutTotal(t *testing.T) {
+	page := `{"next": null, "items": [1, 2, 3]}`
+	ct := newClientTest(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(page))
+		}),
+	)
+	defer ct.Close()
+
+	reports := []Progress{}
+	pager := ct.apiClient.PagerGet(
+		"/leaksdb/sources",
+		nil,
+		WithProgress(func(progress Progress) {
+			reports = append(reports, progress)
+		}),
+	)
+
+	_, err := pager.NextStreaming(
+		context.Background(),
+		func(item json.RawMessage) error {
+			return nil
+		},
+	)
+	if !assert.NoError(t, err, "pager returned an error") {
+		return
+	}
+
+	if !assert.Len(t, reports, 1, "progress should be reported after each page") {
+		return
+	}
+	assert.Equal(t, 3, reports[0].Items)
+	assert.Equal(t, int64(len(page)), reports[0].Bytes)
+	assert.Equal(t, -1, reports[0].Total)
+	assert.Equal(t, float64(0), reports[0].PercentComplete)
+	assert.True(t, reports[0].EstimatedFinish.IsZero(), "the finish time should be unknown")
+}
@@ -0,0 +1,158 @@
+package flareio
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+)
+
+// PageRetryPolicy configures how a page that failed is requested
+// again by the paging iterators and pagers.
+//
+// It is separate from the retries of individual HTTP requests and
+// also covers failures that happen after a response was received,
+// such as truncated bodies, unexpected statuses or invalid JSON.
+type PageRetryPolicy struct {
+	// MaxRetries is the number of times a failed page is requested
+	// again before the error is returned.
+	MaxRetries int
+
+	// WaitMin is the delay before the first retry, it is doubled for
+	// each following retry. Defaults to 2 seconds.
+	WaitMin time.Duration
+
+	// WaitMax is the maximum delay between two retries.
+	// Defaults to 15 seconds.
+	WaitMax time.Duration
+}
+
+// WithPageRetry allows retrying pages that failed with the same cursor
+// before returning the error.
+func WithPageRetry(policy PageRetryPolicy) IterOption {
+	return func(options *iterOptions) {
+		options.pageRetryPolicy = policy
+	}
+}
+
+// wait blocks for the backoff delay that precedes the given retry.
+func (policy PageRetryPolicy) wait(ctx context.Context, retry int) error {
+	waitMin := policy.WaitMin
+	if waitMin <= 0 {
+		waitMin = time.Second * 2
+	}
+	waitMax := policy.WaitMax
+	if waitMax <= 0 {
+		waitMax = time.Second * 15
+	}
+
+	delay := waitMax
+	if retry < 32 {
+		if backoff := waitMin << (retry - 1); backoff > 0 && backoff < waitMax {
+			delay = backoff
+		}
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+type pageErrorAction int
+
+const (
+	pageErrorStop pageErrorAction = iota
+	pageErrorRetry
+	pageErrorSkip
+)
+
+// PageError is returned when a page could not be fetched.
+//
+// By default the iteration stops after a PageError. The consumer can
+// instead call Retry or Skip before asking for the next page.
+type PageError struct {
+	// Cursor is the token of the page that failed.
+	Cursor string
+
+	// Next is the token of the following page, when it could be
+	// determined despite the error.
+	Next string
+
+	// StatusCode is the HTTP status of the response, or 0 if no
+	// response was received.
+	StatusCode int
+
+	// Attempts is the number of times the page was requested.
+	Attempts int
+
+	// Err is the underlying error.
+	Err error
+
+	action pageErrorAction
+
+	// partial is set when some items of the page were already
+	// streamed to the consumer.
+	partial bool
+}
+
+func (e *PageError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *PageError) Unwrap() error {
+	return e.Err
+}
+
+// Retry asks the iteration to request the failed page again.
+func (e *PageError) Retry() {
+	e.action = pageErrorRetry
+}
+
+// Skip asks the iteration to continue with the following page.
+//
+// It returns false if the token of the following page is unknown,
+// in which case the iteration stops.
+func (e *PageError) Skip() bool {
+	if e.Next == "" {
+		return false
+	}
+	e.action = pageErrorSkip
+	return true
+}
+
+// pageRequestError is returned by page fetchers when the request
+// could not be built, which retrying would not fix.
+type pageRequestError struct {
+	err error
+}
+
+func (e *pageRequestError) Error() string {
+	return e.err.Error()
+}
+
+func (e *pageRequestError) Unwrap() error {
+	return e.err
+}
+
+// temporary returns whether requesting the page again could succeed.
+func (e *PageError) temporary() bool {
+	if e.partial {
+		return false
+	}
+	var requestErr *pageRequestError
+	if errors.As(e.Err, &requestErr) {
+		return false
+	}
+	if errors.Is(e.Err, context.Canceled) || errors.Is(e.Err, context.DeadlineExceeded) {
+		return false
+	}
+	if e.StatusCode == http.StatusTooManyRequests {
+		return true
+	}
+	return e.StatusCode < 400 || e.StatusCode >= 500
+}
@@ -0,0 +1,263 @@
+package flareio
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// PageRequest contains the parts of a page request that a
+// PagingStrategy can modify.
+type PageRequest struct {
+	// Params are the query string parameters.
+	Params url.Values
+
+	// Body is the JSON object that is posted, it is nil for
+	// GET requests.
+	Body map[string]interface{}
+}
+
+// setValue sets the value in the body of POST requests and in the
+// query string otherwise.
+func (request *PageRequest) setValue(key string, value string) {
+	if request.Body != nil {
+		request.Body[key] = value
+	} else {
+		request.Params.Set(key, value)
+	}
+}
+
+// setIntValue is like setValue, but keeps integers as numbers
+// in JSON bodies.
+func (request *PageRequest) setIntValue(key string, value int) {
+	if request.Body != nil {
+		request.Body[key] = value
+	} else {
+		request.Params.Set(key, strconv.Itoa(value))
+	}
+}
+
+// PageResponse describes a fetched page to a PagingStrategy.
+type PageResponse struct {
+	// Cursor is the token that was used to fetch the page.
+	Cursor string
+
+	// Fields contains the top level fields of the page, except for
+	// the items array.
+	Fields map[string]json.RawMessage
+
+	// ItemCount is the number of elements in the items array.
+	ItemCount int
+}
+
+// PagingStrategy describes how an endpoint pages its results.
+//
+// Cursors are opaque strings to the iterators and pagers. An empty
+// cursor designates the first page.
+type PagingStrategy interface {
+	// InjectCursor adds the cursor of the page to fetch to the request.
+	InjectCursor(request *PageRequest, cursor string) error
+
+	// NextCursor returns the cursor of the page that follows the given
+	// one, or an empty string if it was the last page.
+	NextCursor(page *PageResponse) (string, error)
+}
+
+// WithPagingStrategy allows configuring how the endpoint pages its
+// results. Defaults to CursorPaging.
+func WithPagingStrategy(strategy PagingStrategy) IterOption {
+	return func(options *iterOptions) {
+		options.pagingStrategy = strategy
+	}
+}
+
+// CursorPaging is the Flare standard paging pattern, where each page
+// contains the cursor of the next page.
+type CursorPaging struct {
+	// NextPath is the path of the next cursor in the page.
+	// Defaults to the top level "next" field.
+	NextPath []string
+
+	// FromKey is the query string parameter or body key used to send
+	// the cursor. Defaults to "from".
+	FromKey string
+}
+
+func (strategy CursorPaging) InjectCursor(request *PageRequest, cursor string) error {
+	if cursor == "" {
+		return nil
+	}
+	fromKey := strategy.FromKey
+	if fromKey == "" {
+		fromKey = "from"
+	}
+	request.setValue(fromKey, cursor)
+	return nil
+}
+
+// usesNextField returns whether the next cursor is the top level
+// "next" field of the page, or is nested in it.
+func (strategy CursorPaging) usesNextField() bool {
+	return len(strategy.NextPath) == 0 || strategy.NextPath[0] == "next"
+}
+
+func (strategy CursorPaging) NextCursor(page *PageResponse) (string, error) {
+	nextPath := strategy.NextPath
+	if len(nextPath) == 0 {
+		nextPath = []string{"next"}
+	}
+
+	fields := page.Fields
+	for _, key := range nextPath[:len(nextPath)-1] {
+		rawNested, ok := fields[key]
+		if !ok {
+			return "", nil
+		}
+		var nested map[string]json.RawMessage
+		if err := json.Unmarshal(rawNested, &nested); err != nil {
+			return "", fmt.Errorf("failed to decode %q: %w", key, err)
+		}
+		fields = nested
+	}
+
+	rawNext, ok := fields[nextPath[len(nextPath)-1]]
+	if !ok {
+		return "", nil
+	}
+	var next *string
+	if err := json.Unmarshal(rawNext, &next); err != nil {
+		return "", fmt.Errorf("failed to decode next cursor: %w", err)
+	}
+	if next == nil {
+		return "", nil
+	}
+	return *next, nil
+}
+
+// OffsetPaging pages through results with an offset and a limit.
+//
+// The iteration stops after a page that has fewer items than the limit.
+type OffsetPaging struct {
+	// Limit is the number of items requested per page. It must be
+	// greater than zero.
+	Limit int
+
+	// OffsetKey is the query string parameter or body key used to send
+	// the offset. Defaults to "offset".
+	OffsetKey string
+
+	// LimitKey is the query string parameter or body key used to send
+	// the limit. Defaults to "limit".
+	LimitKey string
+}
+
+func (strategy OffsetPaging) InjectCursor(request *PageRequest, cursor string) error {
+	if strategy.Limit <= 0 {
+		return fmt.Errorf("invalid offset paging limit: %d", strategy.Limit)
+	}
+	offset, err := parseIntCursor(cursor, 0)
+	if err != nil {
+		return err
+	}
+	offsetKey := strategy.OffsetKey
+	if offsetKey == "" {
+		offsetKey = "offset"
+	}
+	limitKey := strategy.LimitKey
+	if limitKey == "" {
+		limitKey = "limit"
+	}
+	request.setIntValue(offsetKey, offset)
+	request.setIntValue(limitKey, strategy.Limit)
+	return nil
+}
+
+func (strategy OffsetPaging) NextCursor(page *PageResponse) (string, error) {
+	if page.ItemCount == 0 || page.ItemCount < strategy.Limit {
+		return "", nil
+	}
+	offset, err := parseIntCursor(page.Cursor, 0)
+	if err != nil {
+		return "", err
+	}
+	return strconv.Itoa(offset + page.ItemCount), nil
+}
+
+// PageNumberPaging pages through results with a page number and a
+// page size.
+//
+// The iteration stops after a page that has fewer items than the size.
+type PageNumberPaging struct {
+	// Size is the number of items requested per page. It must be
+	// greater than zero.
+	Size int
+
+	// FirstPage is the number of the first page. Defaults to 1, or to
+	// 0 if ZeroBased is set.
+	FirstPage int
+
+	// ZeroBased allows numbering pages from 0, which can't be
+	// configured with FirstPage since 0 is its unset value.
+	ZeroBased bool
+
+	// PageKey is the query string parameter or body key used to send
+	// the page number. Defaults to "page".
+	PageKey string
+
+	// SizeKey is the query string parameter or body key used to send
+	// the page size. Defaults to "size".
+	SizeKey string
+}
+
+func (strategy PageNumberPaging) firstPage() int {
+	if strategy.FirstPage == 0 && !strategy.ZeroBased {
+		return 1
+	}
+	return strategy.FirstPage
+}
+
+func (strategy PageNumberPaging) InjectCursor(request *PageRequest, cursor string) error {
+	if strategy.Size <= 0 {
+		return fmt.Errorf("invalid page number paging size: %d", strategy.Size)
+	}
+	page, err := parseIntCursor(cursor, strategy.firstPage())
+	if err != nil {
+		return err
+	}
+	pageKey := strategy.PageKey
+	if pageKey == "" {
+		pageKey = "page"
+	}
+	sizeKey := strategy.SizeKey
+	if sizeKey == "" {
+		sizeKey = "size"
+	}
+	request.setIntValue(pageKey, page)
+	request.setIntValue(sizeKey, strategy.Size)
+	return nil
+}
+
+func (strategy PageNumberPaging) NextCursor(page *PageResponse) (string, error) {
+	if page.ItemCount == 0 || page.ItemCount < strategy.Size {
+		return "", nil
+	}
+	pageNumber, err := parseIntCursor(page.Cursor, strategy.firstPage())
+	if err != nil {
+		return "", err
+	}
+	return strconv.Itoa(pageNumber + 1), nil
+}
+
+// parseIntCursor parses cursors of strategies that use numbers, an
+// empty cursor designates the given first value.
+func parseIntCursor(cursor string, first int) (int, error) {
+	if cursor == "" {
+		return first, nil
+	}
+	value, err := strconv.Atoi(cursor)
+	if err != nil {
+		return 0, fmt.Errorf("invalid cursor %q: %w", cursor, err)
+	}
+	return value, nil
+}
@@ -0,0 +1,240 @@
+package flareio
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCursorPagingInjectCursor(t *testing.T) {
+	getRequest := &PageRequest{Params: url.Values{}}
+	if !assert.NoError(t, CursorPaging{}.InjectCursor(getRequest, "")) {
+		return
+	}
+	assert.Equal(t, url.Values{}, getRequest.Params, "the first page should not have a cursor")
+
+	if !assert.NoError(t, CursorPaging{}.InjectCursor(getRequest, "second-page")) {
+		return
+	}
+	assert.Equal(t, "second-page", getRequest.Params.Get("from"))
+
+	postRequest := &PageRequest{
+		Params: url.Values{},
+		Body:   map[string]interface{}{},
+	}
+	if !assert.NoError(t, CursorPaging{FromKey: "cursor"}.InjectCursor(postRequest, "second-page")) {
+		return
+	}
+	assert.Equal(t, map[string]interface{}{"cursor": "second-page"}, postRequest.Body)
+	assert.Equal(t, url.Values{}, postRequest.Params, "the cursor should only be in the body")
+}
+
+func TestCursorPagingNestedNextCursor(t *testing.T) {
+	strategy := CursorPaging{
+		NextPath: []string{"paging", "next_cursor"},
+	}
+
+	next, err := strategy.NextCursor(&PageResponse{
+		Fields: map[string]json.RawMessage{
+			"paging": json.RawMessage(`{"next_cursor": "second-page"}`),
+		},
+	})
+	if !assert.NoError(t, err, "failed to get next cursor") {
+		return
+	}
+	assert.Equal(t, "second-page", next)
+
+	next, err = strategy.NextCursor(&PageResponse{
+		Fields: map[string]json.RawMessage{
+			"paging": json.RawMessage(`null`),
+		},
+	})
+	if !assert.NoError(t, err, "failed to get next cursor") {
+		return
+	}
+	assert.Equal(t, "", next, "a null parent should end the iteration")
+
+	_, err = strategy.NextCursor(&PageResponse{
+		Fields: map[string]json.RawMessage{
+			"paging": json.RawMessage(`{"next_cursor": 12}`),
+		},
+	})
+	assert.Error(t, err, "a cursor that isn't a string should be an error")
+}
+
+func TestOffsetPaging(t *testing.T) {
+	strategy := OffsetPaging{Limit: 2}
+
+	request := &PageRequest{Params: url.Values{}}
+	if !assert.NoError(t, strategy.InjectCursor(request, "")) {
+		return
+	}
+	assert.Equal(t, "0", request.Params.Get("offset"))
+	assert.Equal(t, "2", request.Params.Get("limit"))
+
+	next, err := strategy.NextCursor(&PageResponse{Cursor: "4", ItemCount: 2})
+	if !assert.NoError(t, err, "failed to get next cursor") {
+		return
+	}
+	assert.Equal(t, "6", next)
+
+	next, err = strategy.NextCursor(&PageResponse{Cursor: "6", ItemCount: 1})
+	if !assert.NoError(t, err, "failed to get next cursor") {
+		return
+	}
+	assert.Equal(t, "", next, "a partial page should end the iteration")
+
+	assert.Error(
+		t,
+		strategy.InjectCursor(&PageRequest{Params: url.Values{}}, "not-a-number"),
+		"invalid cursors should be an error",
+	)
+
+	for _, limit := range []int{0, -1} {
+		assert.EqualError(
+			t,
+			OffsetPaging{Limit: limit}.InjectCursor(&PageRequest{Params: url.Values{}}, ""),
+			fmt.Sprintf("invalid offset paging limit: %d", limit),
+		)
+	}
+}
+
+func TestPageNumberPaging(t *testing.T) {
+	strategy := PageNumberPaging{Size: 10, PageKey: "page_number"}
+
+	request := &PageRequest{
+		Params: url.Values{},
+		Body:   map[string]interface{}{},
+	}
+	if !assert.NoError(t, strategy.InjectCursor(request, "")) {
+		return
+	}
+	assert.Equal(
+		t,
+		map[string]interface{}{"page_number": 1, "size": 10},
+		request.Body,
+	)
+
+	next, err := strategy.NextCursor(&PageResponse{Cursor: "", ItemCount: 10})
+	if !assert.NoError(t, err, "failed to get next cursor") {
+		return
+	}
+	assert.Equal(t, "2", next)
+
+	next, err = strategy.NextCursor(&PageResponse{Cursor: "2", ItemCount: 0})
+	if !assert.NoError(t, err, "failed to get next cursor") {
+		return
+	}
+	assert.Equal(t, "", next, "an empty page should end the iteration")
+
+	assert.EqualError(
+		t,
+		PageNumberPaging{}.InjectCursor(&PageRequest{Params: url.Values{}}, ""),
+		"invalid page number paging size: 0",
+	)
+}
+
+func TestPageNumberPagingFirstPage(t *testing.T) {
+	for _, tc := range []struct {
+		strategy PageNumberPaging
+		first    string
+		next     string
+	}{
+		{PageNumberPaging{Size: 10}, "1", "2"},
+		{PageNumberPaging{Size: 10, ZeroBased: true}, "0", "1"},
+		{PageNumberPaging{Size: 10, FirstPage: 5}, "5", "6"},
+	} {
+		request := &PageRequest{Params: url.Values{}}
+		if !assert.NoError(t, tc.strategy.InjectCursor(request, "")) {
+			continue
+		}
+		assert.Equal(t, tc.first, request.Params.Get("page"))
+
+		next, err := tc.strategy.NextCursor(&PageResponse{Cursor: "", ItemCount: 10})
+		if assert.NoError(t, err, "failed to get next cursor") {
+			assert.Equal(t, tc.next, next)
+		}
+	}
+}
+
+func TestPagerGetOffsetPaging(t *testing.T) {
+	offsets := []string{}
+	ct := newClientTest(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "", r.URL.Query().Get("from"), "the cursor should not be sent as from")
+			assert.Equal(t, "2", r.URL.Query().Get("limit"))
+			offset := r.URL.Query().Get("offset")
+			offsets = append(offsets, offset)
+			if offset == "4" {
+				w.Write([]byte(`{"items": [5]}`))
+			} else {
+				w.Write([]byte(`{"items": [1, 2]}`))
+			}
+		}),
+	)
+	defer ct.Close()
+
+	pager := ct.apiClient.PagerGet(
+		"/some-path",
+		nil,
+		WithPagingStrategy(OffsetPaging{Limit: 2}),
+	)
+	for pager.HasMore() {
+		if len(offsets) > 5 {
+			// We are going crazy here...
+			break
+		}
+		if _, err := pager.Next(context.Background()); !assert.NoError(t, err, "pager returned an error") {
+			return
+		}
+	}
+
+	assert.Equal(t, []string{"0", "2", "4"}, offsets)
+}
+
+func TestPagerPostJsonPageNumberPaging(t *testing.T) {
+	pages := []int{}
+	ct := newClientTest(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			type PagedRequest struct {
+				Page int `json:"page"`
+				Size int `json:"size"`
+			}
+			var pagedRequest PagedRequest
+			if err := json.NewDecoder(r.Body).Decode(&pagedRequest); !assert.NoError(t, err, "Error decoding posted JSON") {
+				return
+			}
+			assert.Equal(t, 1, pagedRequest.Size)
+			pages = append(pages, pagedRequest.Page)
+			if pagedRequest.Page < 3 {
+				w.Write([]byte(`{"items": [1]}`))
+			} else {
+				w.Write([]byte(`{"items": []}`))
+			}
+		}),
+	)
+	defer ct.Close()
+
+	pager := ct.apiClient.PagerPostJson(
+		"/some-path",
+		nil,
+		nil,
+		WithPagingStrategy(PageNumberPaging{Size: 1}),
+	)
+	for pager.HasMore() {
+		if len(pages) > 5 {
+			// We are going crazy here...
+			break
+		}
+		if _, err := pager.Next(context.Background()); !assert.NoError(t, err, "pager returned an error") {
+			return
+		}
+	}
+
+	assert.Equal(t, []int{1, 2, 3}, pages)
+}
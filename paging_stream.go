@@ -0,0 +1,218 @@
+package flareio
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// pageConsumerError wraps errors returned by the consumer of a
+// streamed page so that they can be told apart from decoding errors.
+type pageConsumerError struct {
+	err error
+}
+
+func (e *pageConsumerError) Error() string {
+	return e.err.Error()
+}
+
+func (e *pageConsumerError) Unwrap() error {
+	return e.err
+}
+
+// decodePageStream tokenizes a page and calls onItem for each element
+// of its items array as soon as it is decoded. It returns the other top
+// level fields of the page, wherever they appear.
+//
+// The fields that were decoded before an error are also returned.
+func decodePageStream(
+	r io.Reader,
+	onItem func(item json.RawMessage) error,
+) (map[string]json.RawMessage, error) {
+	decoder := json.NewDecoder(r)
+
+	fields := make(map[string]json.RawMessage)
+	if err := expectDelim(decoder, '{'); err != nil {
+		return fields, err
+	}
+
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return fields, err
+		}
+		key, ok := token.(string)
+		if !ok {
+			return fields, fmt.Errorf("expected object key, got %v", token)
+		}
+
+		if key == "items" {
+			if err := decodeItemsStream(decoder, onItem); err != nil {
+				return fields, err
+			}
+			continue
+		}
+
+		var value json.RawMessage
+		if err := decoder.Decode(&value); err != nil {
+			return fields, fmt.Errorf("failed to decode %q: %w", key, err)
+		}
+		fields[key] = value
+	}
+
+	if err := expectDelim(decoder, '}'); err != nil {
+		return fields, err
+	}
+	return fields, nil
+}
+
+func decodeItemsStream(
+	decoder *json.Decoder,
+	onItem func(item json.RawMessage) error,
+) error {
+	token, err := decoder.Token()
+	if err != nil {
+		return err
+	}
+	if token == nil {
+		return nil
+	}
+	if delim, ok := token.(json.Delim); !ok || delim != '[' {
+		return fmt.Errorf("expected items to be an array, got %v", token)
+	}
+
+	for decoder.More() {
+		var item json.RawMessage
+		if err := decoder.Decode(&item); err != nil {
+			return fmt.Errorf("failed to decode item: %w", err)
+		}
+		if err := onItem(item); err != nil {
+			return err
+		}
+	}
+
+	return expectDelim(decoder, ']')
+}
+
+// decodePageFields returns the top level fields of a buffered page
+// without its items, which are walked token by token so that they are
+// never copied. They are only counted if countItems is set, and items
+// that are not an array are considered empty.
+//
+// The fields that were decoded before an error are also returned.
+func decodePageFields(body []byte, countItems bool) (map[string]json.RawMessage, int, error) {
+	decoder := json.NewDecoder(bytes.NewReader(body))
+
+	fields := make(map[string]json.RawMessage)
+	if err := expectDelim(decoder, '{'); err != nil {
+		return fields, 0, err
+	}
+
+	itemCount := 0
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return fields, 0, err
+		}
+		key, ok := token.(string)
+		if !ok {
+			return fields, 0, fmt.Errorf("expected object key, got %v", token)
+		}
+
+		if key == "items" {
+			elements, err := skipValue(decoder)
+			if err != nil {
+				return fields, 0, fmt.Errorf("failed to decode %q: %w", key, err)
+			}
+			if countItems {
+				itemCount = elements
+			}
+			continue
+		}
+
+		var value json.RawMessage
+		if err := decoder.Decode(&value); err != nil {
+			return fields, 0, fmt.Errorf("failed to decode %q: %w", key, err)
+		}
+		fields[key] = value
+	}
+
+	if err := expectDelim(decoder, '}'); err != nil {
+		return fields, 0, err
+	}
+	return fields, itemCount, nil
+}
+
+// decodeCursorPageFields returns the "next" and "total" fields of a
+// buffered page, which are the only ones that CursorPaging needs when
+// the items are not counted. They are unmarshalled like any other
+// struct, which is much cheaper than walking the items.
+//
+// The fields that were decoded before an error are also returned.
+func decodeCursorPageFields(body []byte) (map[string]json.RawMessage, error) {
+	var page struct {
+		Next  json.RawMessage `json:"next"`
+		Total json.RawMessage `json:"total"`
+	}
+	if err := json.Unmarshal(body, &page); err != nil {
+		// Unmarshal doesn't decode anything from an invalid page, so
+		// walk it to find the fields that precede the error.
+		fields, _, _ := decodePageFields(body, false)
+		return fields, err
+	}
+
+	fields := make(map[string]json.RawMessage, 2)
+	if page.Next != nil {
+		fields["next"] = page.Next
+	}
+	if page.Total != nil {
+		fields["total"] = page.Total
+	}
+	return fields, nil
+}
+
+// skipValue reads the next value of the decoder token by token. It
+// returns the number of elements of the value if it is an array.
+func skipValue(decoder *json.Decoder) (int, error) {
+	token, err := decoder.Token()
+	if err != nil {
+		return 0, err
+	}
+	delim, ok := token.(json.Delim)
+	if !ok {
+		return 0, nil
+	}
+
+	elements := 0
+	for decoder.More() {
+		if delim == '{' {
+			// Skip the key of the member.
+			if _, err := decoder.Token(); err != nil {
+				return 0, err
+			}
+		}
+		if _, err := skipValue(decoder); err != nil {
+			return 0, err
+		}
+		elements = elements + 1
+	}
+	if _, err := decoder.Token(); err != nil {
+		return 0, err
+	}
+	if delim != '[' {
+		return 0, nil
+	}
+	return elements, nil
+}
+
+func expectDelim(decoder *json.Decoder, expected json.Delim) error {
+	token, err := decoder.Token()
+	if err != nil {
+		return err
+	}
+	if delim, ok := token.(json.Delim); !ok || delim != expected {
+		return fmt.Errorf("expected %v, got %v", expected, token)
+	}
+	return nil
+}
@@ -0,0 +1,140 @@
+package flareio
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func decodeTestPageStream(page string) ([]string, string, error) {
+	items := []string{}
+	fields, err := decodePageStream(
+		strings.NewReader(page),
+		func(item json.RawMessage) error {
+			items = append(items, string(item))
+			return nil
+		},
+	)
+	next, _ := CursorPaging{}.NextCursor(&PageResponse{Fields: fields})
+	return items, next, err
+}
+
+func TestDecodePageStreamNextAfterItems(t *testing.T) {
+	items, next, err := decodeTestPageStream(
+		`{"items": [{"id": 1}, {"id": 2}], "other": {"a": [1, 2]}, "next": "second-page"}`,
+	)
+	if !assert.NoError(t, err, "failed to decode page") {
+		return
+	}
+	assert.Equal(t, []string{`{"id": 1}`, `{"id": 2}`}, items)
+	assert.Equal(t, "second-page", next)
+}
+
+func TestDecodePageStreamNextBeforeItems(t *testing.T) {
+	items, next, err := decodeTestPageStream(
+		`{"next": "second-page", "items": [1]}`,
+	)
+	if !assert.NoError(t, err, "failed to decode page") {
+		return
+	}
+	assert.Equal(t, []string{`1`}, items)
+	assert.Equal(t, "second-page", next)
+}
+
+func TestDecodePageStreamNullValues(t *testing.T) {
+	items, next, err := decodeTestPageStream(
+		`{"next": null, "items": null}`,
+	)
+	if !assert.NoError(t, err, "failed to decode page") {
+		return
+	}
+	assert.Equal(t, []string{}, items)
+	assert.Equal(t, "", next)
+}
+
+func TestDecodePageStreamTruncated(t *testing.T) {
+	items, next, err := decodeTestPageStream(
+		`{"next": "second-page", "items": [1, 2, {"id":`,
+	)
+	assert.Error(t, err, "a truncated page should be an error")
+	assert.Equal(t, []string{`1`, `2`}, items, "items before the error should be decoded")
+	assert.Equal(t, "second-page", next, "next should be known when decoded before the error")
+}
+
+func TestDecodePageStreamBadItems(t *testing.T) {
+	_, _, err := decodeTestPageStream(
+		`{"items": {"id": 1}}`,
+	)
+	assert.ErrorContains(t, err, "expected items to be an array")
+}
+
+func TestDecodePageStreamNotAnObject(t *testing.T) {
+	_, _, err := decodeTestPageStream(
+		`[1, 2]`,
+	)
+	assert.Error(t, err, "a page that isn't an object should be an error")
+}
+
+func TestDecodePageFields(t *testing.T) {
+	fields, itemCount, err := decodePageFields(
+		[]byte(`{"items": [{"id": 1, "tags": ["a", "b"]}, [1, 2], "3"], "next": "second-page"}`),
+		true,
+	)
+	if !assert.NoError(t, err, "failed to decode page") {
+		return
+	}
+	assert.Equal(t, 3, itemCount)
+	assert.Equal(t, map[string]json.RawMessage{"next": json.RawMessage(`"second-page"`)}, fields)
+
+	_, itemCount, err = decodePageFields([]byte(`{"items": [1, 2], "next": null}`), false)
+	if assert.NoError(t, err, "failed to decode page") {
+		assert.Equal(t, 0, itemCount, "items should only be counted when needed")
+	}
+
+	for _, page := range []string{
+		`{"items": {"id": 1}, "next": "second-page"}`,
+		`{"items": null, "next": "second-page"}`,
+		`{"items": 3, "next": "second-page"}`,
+	} {
+		fields, itemCount, err := decodePageFields([]byte(page), true)
+		if assert.NoError(t, err, "items that are not an array should be accepted") {
+			assert.Equal(t, 0, itemCount)
+			assert.Equal(t, json.RawMessage(`"second-page"`), fields["next"])
+		}
+	}
+
+	_, _, err = decodePageFields([]byte(`{"items": [1, 2`), true)
+	assert.Error(t, err)
+}
+
+func TestDecodeCursorPageFields(t *testing.T) {
+	fields, err := decodeCursorPageFields(
+		[]byte(`{"items": [{"id": 1}, 2], "next": "second-page", "total": {"value": 12}, "other": true}`),
+	)
+	if assert.NoError(t, err, "failed to decode page") {
+		assert.Equal(
+			t,
+			map[string]json.RawMessage{
+				"next":  json.RawMessage(`"second-page"`),
+				"total": json.RawMessage(`{"value": 12}`),
+			},
+			fields,
+		)
+	}
+
+	fields, err = decodeCursorPageFields([]byte(`{"items": [], "next": null}`))
+	if assert.NoError(t, err, "failed to decode page") {
+		assert.Equal(t, map[string]json.RawMessage{"next": json.RawMessage(`null`)}, fields)
+	}
+
+	fields, err = decodeCursorPageFields([]byte(`{"next": "second-page", "items": [1, `))
+	assert.Error(t, err)
+	assert.Equal(
+		t,
+		map[string]json.RawMessage{"next": json.RawMessage(`"second-page"`)},
+		fields,
+		"the fields before the error should be returned",
+	)
+}
@@ -0,0 +1,363 @@
+package flareio
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPagerGet(t *testing.T) {
+	ct := newClientTest(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/leaksdb/sources", r.URL.Path)
+			assert.Equal(t, "value1", r.URL.Query().Get("param1"))
+
+			cursor := r.URL.Query().Get("from")
+			if cursor == "" {
+				w.Write([]byte(`{"next":"second-page", "items": []}`))
+			} else if cursor == "second-page" {
+				w.Write([]byte(`{"next":"third-page", "items": []}`))
+			} else {
+				w.Write([]byte(`{"next": null, "items": []}`))
+			}
+		}),
+	)
+	defer ct.Close()
+
+	pager := ct.apiClient.PagerGet(
+		"/leaksdb/sources",
+		&url.Values{
+			"param1": []string{"value1"},
+		},
+	)
+
+	cursors := []string{}
+	nextTokens := []string{}
+	for pager.HasMore() {
+		if len(cursors) > 5 {
+			// We are going crazy here...
+			break
+		}
+		result, err := pager.Next(context.Background())
+		if !assert.NoError(t, err, "pager returned an error") {
+			return
+		}
+		result.Response.Body.Close()
+		cursors = append(cursors, result.Cursor)
+		nextTokens = append(nextTokens, result.Next)
+	}
+
+	assert.Equal(t, []string{"", "second-page", "third-page"}, cursors)
+	assert.Equal(t, []string{"second-page", "third-page", ""}, nextTokens)
+
+	_, err := pager.Next(context.Background())
+	assert.ErrorIs(t, err, ErrNoMorePages)
+}
+
+func TestPagerPostJson(t *testing.T) {
+	ct := newClientTest(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+
+			type PagedRequest struct {
+				From      string `json:"from"`
+				SomeParam string `json:"some_param"`
+			}
+			var pagedRequest PagedRequest
+			if err := json.NewDecoder(r.Body).Decode(&pagedRequest); !assert.NoError(t, err, "Error decoding posted JSON") {
+				return
+			}
+			assert.Equal(t, "hello", pagedRequest.SomeParam)
+
+			if pagedRequest.From == "" {
+				w.Write([]byte(`{"next":"second-page", "items": []}`))
+			} else {
+				w.Write([]byte(`{"next": null, "items": []}`))
+			}
+		}),
+	)
+	defer ct.Close()
+
+	pager := ct.apiClient.PagerPostJson(
+		"/leaksdb/sources",
+		nil,
+		map[string]interface{}{
+			"some_param": "hello",
+		},
+	)
+
+	nextTokens := []string{}
+	for pager.HasMore() {
+		if len(nextTokens) > 5 {
+			// We are going crazy here...
+			break
+		}
+		result, err := pager.Next(context.Background())
+		if !assert.NoError(t, err, "pager returned an error") {
+			return
+		}
+		result.Response.Body.Close()
+		nextTokens = append(nextTokens, result.Next)
+	}
+
+	assert.Equal(t, []string{"second-page", ""}, nextTokens)
+}
+
+func TestPagerError(t *testing.T) {
+	ct := newClientTest(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`"some error"`))
+		}),
+	)
+	defer ct.Close()
+
+	pager := ct.apiClient.PagerGet("/leaksdb/sources", nil)
+
+	result, err := pager.Next(context.Background())
+	assert.ErrorContains(t, err, "got http status code 400")
+	assert.Nil(t, result, "result should be nil on errors")
+	assert.False(t, pager.HasMore(), "the pager should stop after an error")
+}
+
+func TestPagerContextCanceled(t *testing.T) {
+	ct := newClientTest(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(`{"next": null, "items": []}`))
+		}),
+	)
+	defer ct.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	pager := ct.apiClient.PagerGet("/leaksdb/sources", nil)
+
+	_, err := pager.Next(ctx)
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestPagerContextCanceledBeforeCursorLoop(t *testing.T) {
+	ct := newClientTest(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(`{"next": "same-page", "items": []}`))
+		}),
+	)
+	defer ct.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	pager := ct.apiClient.PagerGet("/leaksdb/sources", nil)
+	for i := 0; i < 2; i++ {
+		result, err := pager.Next(ctx)
+		if !assert.NoError(t, err) {
+			return
+		}
+		result.Response.Body.Close()
+	}
+
+	// The next page would be a cursor loop, but the cancellation is
+	// reported first.
+	cancel()
+	_, err := pager.Next(ctx)
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestPagerCheckpointer(t *testing.T) {
+	ct := newClientTest(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			cursor := r.URL.Query().Get("from")
+			if cursor == "" {
+				w.Write([]byte(`{"next":"second-page", "items": []}`))
+			} else if cursor == "second-page" {
+				w.Write([]byte(`{"next":"third-page", "items": []}`))
+			} else {
+				w.Write([]byte(`{"next": null, "items": []}`))
+			}
+		}),
+	)
+	defer ct.Close()
+
+	checkpointer := NewFileCheckpointer(
+		filepath.Join(t.TempDir(), "checkpoint"),
+	)
+
+	pager := ct.apiClient.PagerGet(
+		"/leaksdb/sources",
+		nil,
+		WithCheckpointer(checkpointer),
+	)
+	for i := 0; i < 2; i++ {
+		_, err := pager.Next(context.Background())
+		if !assert.NoError(t, err, "pager returned an error") {
+			return
+		}
+	}
+
+	cursor, err := checkpointer.LoadCursor()
+	if !assert.NoError(t, err, "failed to load checkpoint") {
+		return
+	}
+	assert.Equal(t, "second-page", cursor, "only the first page should be processed")
+
+	resumedPager := ct.apiClient.PagerGet(
+		"/leaksdb/sources",
+		nil,
+		WithCheckpointer(checkpointer),
+	)
+	result, err := resumedPager.Next(context.Background())
+	if !assert.NoError(t, err, "pager returned an error") {
+		return
+	}
+	assert.Equal(t, "second-page", result.Cursor, "the pager should resume from the checkpoint")
+
+	for resumedPager.HasMore() {
+		_, err := resumedPager.Next(context.Background())
+		if !assert.NoError(t, err, "pager returned an error") {
+			return
+		}
+	}
+	cursor, err = checkpointer.LoadCursor()
+	if !assert.NoError(t, err, "failed to load checkpoint") {
+		return
+	}
+	assert.Equal(t, checkpointCompleted, cursor, "the pager should be marked as completed")
+
+	completedPager := ct.apiClient.PagerGet(
+		"/leaksdb/sources",
+		nil,
+		WithCheckpointer(checkpointer),
+	)
+	assert.False(t, completedPager.HasMore(), "a completed pager should have no pages")
+	_, err = completedPager.Next(context.Background())
+	assert.ErrorIs(t, err, ErrNoMorePages)
+}
+
+func TestPagerCheckpointerCompletionError(t *testing.T) {
+	ct := newClientTest(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(`{"next": null, "items": []}`))
+		}),
+	)
+	defer ct.Close()
+
+	// The checkpoint cannot be saved in a directory that doesn't exist.
+	checkpointer := NewFileCheckpointer(
+		filepath.Join(t.TempDir(), "missing", "checkpoint"),
+	)
+	pager := ct.apiClient.PagerGet(
+		"/leaksdb/sources",
+		nil,
+		WithCheckpointer(checkpointer),
+	)
+	_, err := pager.Next(context.Background())
+	if !assert.NoError(t, err, "pager returned an error") {
+		return
+	}
+
+	assert.True(t, pager.HasMore(), "the completion error should be returned by Next")
+	_, err = pager.Next(context.Background())
+	assert.ErrorContains(t, err, "failed to save checkpoint")
+	assert.False(t, pager.HasMore())
+}
+
+func TestPagerMaxPages(t *testing.T) {
+	ct := newClientTest(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(`{"next":"another-page", "items": []}`))
+		}),
+	)
+	defer ct.Close()
+
+	pager := ct.apiClient.PagerGet(
+		"/leaksdb/sources",
+		nil,
+		WithMaxPages(2),
+	)
+
+	for i := 0; i < 2; i++ {
+		_, err := pager.Next(context.Background())
+		if !assert.NoError(t, err, "pager returned an error") {
+			return
+		}
+	}
+
+	_, err := pager.Next(context.Background())
+	assert.ErrorIs(t, err, ErrPagingLimitReached)
+	assert.False(t, pager.HasMore(), "the pager should stop once the limit is reached")
+}
+
+func TestPagerConsumerRetry(t *testing.T) {
+	requestsReceived := 0
+	ct := newClientTest(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requestsReceived = requestsReceived + 1
+			if requestsReceived == 1 {
+				w.Write([]byte(`{"next": "sec`))
+			} else {
+				w.Write([]byte(`{"next": null, "items": []}`))
+			}
+		}),
+	)
+	defer ct.Close()
+
+	pager := ct.apiClient.PagerGet("/leaksdb/sources", nil)
+
+	_, err := pager.Next(context.Background())
+	var pageErr *PageError
+	if !assert.ErrorAs(t, err, &pageErr) {
+		return
+	}
+	assert.False(t, pager.HasMore(), "the pager should stop after an error by default")
+
+	pageErr.Retry()
+	assert.True(t, pager.HasMore(), "the pager should resume after a retry")
+
+	result, err := pager.Next(context.Background())
+	if !assert.NoError(t, err, "pager returned an error") {
+		return
+	}
+	assert.Equal(t, "", result.Cursor, "the failed page should be requested again")
+	assert.False(t, pager.HasMore())
+}
+
+func TestPagerNextStreaming(t *testing.T) {
+	ct := newClientTest(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Query().Get("from") == "" {
+				w.Write([]byte(`{"items": [1, 2], "next": "second-page"}`))
+			} else {
+				w.Write([]byte(`{"items": [3], "next": null}`))
+			}
+		}),
+	)
+	defer ct.Close()
+
+	pager := ct.apiClient.PagerGet("/leaksdb/sources", nil)
+
+	items := []string{}
+	for pager.HasMore() {
+		if len(items) > 5 {
+			// We are going crazy here...
+			break
+		}
+		_, err := pager.NextStreaming(
+			context.Background(),
+			func(item json.RawMessage) error {
+				items = append(items, string(item))
+				return nil
+			},
+		)
+		if !assert.NoError(t, err, "pager returned an error") {
+			return
+		}
+	}
+
+	assert.Equal(t, []string{"1", "2", "3"}, items)
+}
@@ -0,0 +1,48 @@
+package flareio
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// rateLimiter spaces out requests so that they are at least
+// interval apart.
+type rateLimiter struct {
+	interval time.Duration
+
+	mu   sync.Mutex
+	next time.Time
+}
+
+func newRateLimiter(interval time.Duration) *rateLimiter {
+	return &rateLimiter{
+		interval: interval,
+	}
+}
+
+// wait blocks until a request can be performed or the context is done.
+func (limiter *rateLimiter) wait(ctx context.Context) error {
+	limiter.mu.Lock()
+	now := time.Now()
+	at := limiter.next
+	if at.Before(now) {
+		at = now
+	}
+	limiter.next = at.Add(limiter.interval)
+	limiter.mu.Unlock()
+
+	delay := time.Until(at)
+	if delay <= 0 {
+		return ctx.Err()
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,39 @@
+package flareio
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRateLimiterSpacesRequests(t *testing.T) {
+	limiter := newRateLimiter(time.Millisecond * 20)
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		if !assert.NoError(t, limiter.wait(context.Background())) {
+			return
+		}
+	}
+
+	assert.GreaterOrEqual(
+		t,
+		time.Since(start),
+		time.Millisecond*40,
+		"requests should be spaced by the interval",
+	)
+}
+
+func TestRateLimiterContextCanceled(t *testing.T) {
+	limiter := newRateLimiter(time.Hour)
+	if !assert.NoError(t, limiter.wait(context.Background())) {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*10)
+	defer cancel()
+
+	assert.ErrorIs(t, limiter.wait(ctx), context.DeadlineExceeded)
+}